@@ -0,0 +1,326 @@
+// Package chain keeps track of the head of the mock chain, shared by the gRPC and HTTP servers.
+package chain
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"zero-provers/server/dataset"
+	"zero-provers/server/logger"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/rs/zerolog"
+)
+
+// EndAction is what the chain does once its head moves past the last block of the dataset.
+type EndAction string
+
+var (
+	// HoldAction keeps the head on the last block of the dataset.
+	HoldAction EndAction = "hold"
+	// LoopAction restarts from the first block of the dataset, renumbered to keep heights increasing.
+	LoopAction EndAction = "loop"
+	// ExitAction keeps the head on the last block of the dataset and closes the `Done` channel.
+	ExitAction EndAction = "exit"
+)
+
+const (
+	// Maximum number of headers listed in an event.
+	maxEventHeaders = 1024
+	// Maximum number of blocks the head moves forward at once in loop mode. The hash of each looped block
+	// depends on all the previous ones, so they are computed up to the new head while the lock is held.
+	maxLoopLead = 10_000
+)
+
+// ErrTooFarAhead is returned when the head is moved too far forward at once in loop mode.
+var ErrTooFarAhead = fmt.Errorf("looped blocks are at most %d blocks ahead of the head", maxLoopLead)
+
+// Log is the package-level variable used for logging messages and errors.
+var log zerolog.Logger
+
+// Config contains the dataset the chain is made of.
+type Config struct {
+	LogLevel     zerolog.Level
+	Dataset      *dataset.Store
+	OnDatasetEnd EndAction
+}
+
+// Chain holds the head of the mock chain, as a position in the dataset.
+// In loop mode, positions past the end of the dataset map to the dataset blocks, renumbered and re-linked
+// so that the chain stays consistent. Otherwise, the head stays on the last block when it reaches the end
+// of the dataset, or when the dataset shrinks after a reload.
+type Chain struct {
+	dataset  *dataset.Store
+	onEnd    EndAction
+	position int
+
+	// Hashes of the looped blocks, starting at the position right after the last block of the dataset,
+	// along with the index they were computed from.
+	loopHashes []types.Hash
+	loopIndex  *dataset.Index
+
+	// Events published every time the head moves.
+	feed Feed
+
+	// Closed once the head moves past the last block in exit mode.
+	done     chan struct{}
+	doneOnce sync.Once
+
+	lock sync.Mutex
+}
+
+// New returns a chain whose head is the first block of the dataset.
+func New(config Config) *Chain {
+	// Set up the logger.
+	lc := logger.LoggerConfig{
+		Level:       config.LogLevel,
+		CallerField: "chain",
+	}
+	log = logger.NewLogger(lc)
+
+	return &Chain{
+		dataset: config.Dataset,
+		onEnd:   config.OnDatasetEnd,
+		done:    make(chan struct{}),
+	}
+}
+
+// Done returns a channel closed once the head moves past the last block of the dataset in exit mode.
+func (c *Chain) Done() <-chan struct{} {
+	return c.done
+}
+
+// Subscribe returns a channel receiving an event every time the head moves, along with a function to
+// unsubscribe.
+func (c *Chain) Subscribe() (<-chan Event, func()) {
+	return c.feed.Subscribe()
+}
+
+// Head returns the entry at the head of the chain.
+func (c *Chain) Head() *dataset.Entry {
+	index := c.dataset.Index()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.entry(index, c.bound(index, c.position))
+}
+
+// First returns the entry of the first block of the chain.
+func (c *Chain) First() *dataset.Entry {
+	return c.dataset.Index().At(0)
+}
+
+// Get returns the entry of the given block number.
+// Looped blocks are only available once the head of the chain reached them.
+func (c *Chain) Get(number uint64) (*dataset.Entry, bool) {
+	index := c.dataset.Index()
+	if entry, ok := index.Get(number); ok {
+		return entry, true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	position, ok := c.positionOf(index, number)
+	if !ok || position > c.bound(index, c.position) {
+		return nil, false
+	}
+	return c.entry(index, position), true
+}
+
+// Advance moves the head `n` blocks forward and returns the new head.
+// The move is saturated rather than overflowing on large values of `n`.
+func (c *Chain) Advance(n int) *dataset.Entry {
+	index := c.dataset.Index()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	position := c.bound(index, c.position)
+	return c.move(index, position+min(n, math.MaxInt-position))
+}
+
+// SetHead moves the head to the given block number and returns the new head.
+// It fails if the block isn't part of the dataset, or if it is a looped block too far ahead of the head.
+func (c *Chain) SetHead(number uint64) (*dataset.Entry, error) {
+	index := c.dataset.Index()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	position, ok := c.positionOf(index, number)
+	if !ok {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	if position > c.loopLimit(index) {
+		return nil, fmt.Errorf("unable to move to block #%d: %w", number, ErrTooFarAhead)
+	}
+	return c.move(index, position), nil
+}
+
+// SetPosition moves the head to the block at the given position in the dataset and returns the new head.
+func (c *Chain) SetPosition(position int) *dataset.Entry {
+	index := c.dataset.Index()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.move(index, position)
+}
+
+// Return the head of the chain and the block right after it, nil if the head is the last block.
+func (c *Chain) peek() (head, next *dataset.Entry) {
+	index := c.dataset.Index()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	position := c.bound(index, c.position)
+	if c.onEnd == LoopAction || position+1 < index.Len() {
+		next = c.entry(index, position+1)
+	}
+	return c.entry(index, position), next
+}
+
+// Move the head to the given position, the lock being held by the caller.
+// In loop mode, the head moves at most `maxLoopLead` blocks forward at once, see `loopLimit`.
+func (c *Chain) move(index *dataset.Index, position int) *dataset.Entry {
+	if position >= index.Len() && c.onEnd == ExitAction {
+		c.doneOnce.Do(func() {
+			log.Info().Msgf("End of the dataset reached after block #%d", index.At(index.Len()-1).Number)
+			close(c.done)
+		})
+	}
+
+	if limit := c.loopLimit(index); c.onEnd == LoopAction && position > limit {
+		log.Warn().Msgf("Chain head moved to block #%d only, looped blocks are at most %d blocks ahead of the head",
+			c.header(index, limit).Number, maxLoopLead)
+		position = limit
+	}
+
+	position = c.bound(index, position)
+	head := c.entry(index, position)
+	if previous := c.bound(index, c.position); position != previous {
+		log.Debug().Msgf("Chain head moved to block #%d", head.Number)
+		c.feed.Publish(c.event(index, previous, position))
+	}
+	c.position = position
+	return head
+}
+
+// Return the event describing a move of the head between two positions, the lock being held by the caller.
+// Only the last `maxEventHeaders` added or removed headers are listed on large moves.
+func (c *Chain) event(index *dataset.Index, from, to int) Event {
+	var event Event
+	if to > from {
+		for position := max(from+1, to-maxEventHeaders+1); position <= to; position++ {
+			event.Added = append(event.Added, c.header(index, position))
+		}
+	} else {
+		for position := from; position > max(to, from-maxEventHeaders); position-- {
+			event.Removed = append(event.Removed, c.header(index, position))
+		}
+		event.Added = []Header{c.header(index, to)}
+	}
+	return event
+}
+
+// Return the header of the block at the given position, the lock being held by the caller.
+func (c *Chain) header(index *dataset.Index, position int) Header {
+	if position < index.Len() {
+		entry := index.At(position)
+		return Header{Number: entry.Number, Hash: entry.Block.Hash()}
+	}
+	header := c.loopHeader(index, position)
+	return Header{Number: header.Number, Hash: header.Hash}
+}
+
+// Return the last position the head can move to at once in loop mode, `maxLoopLead` blocks past the head or
+// past the last block of the dataset, the lock being held by the caller.
+func (c *Chain) loopLimit(index *dataset.Index) int {
+	return max(c.bound(index, c.position), index.Len()-1) + maxLoopLead
+}
+
+// Keep a position within the bounds of the dataset. Positions past the last block are only valid in loop mode.
+func (c *Chain) bound(index *dataset.Index, position int) int {
+	if c.onEnd != LoopAction {
+		position = min(position, index.Len()-1)
+	}
+	return max(0, position)
+}
+
+// Return the position of the given block number, looped blocks included.
+func (c *Chain) positionOf(index *dataset.Index, number uint64) (int, bool) {
+	if position, ok := index.Position(number); ok {
+		return position, true
+	}
+
+	first := index.At(0).Number
+	if c.onEnd != LoopAction || number < first {
+		return 0, false
+	}
+	lap := (number - first) / numberSpan(index)
+	position, ok := index.Position(number - lap*numberSpan(index))
+	if !ok || lap > uint64((math.MaxInt-position)/index.Len()) {
+		return 0, false
+	}
+	return int(lap)*index.Len() + position, true
+}
+
+// Return the entry at the given position, the lock being held by the caller.
+func (c *Chain) entry(index *dataset.Index, position int) *dataset.Entry {
+	if position < index.Len() {
+		return index.At(position)
+	}
+
+	// Build the looped block out of the dataset block it is a copy of.
+	base := index.At(position % index.Len())
+	block := &types.Block{
+		Header:       c.loopHeader(index, position),
+		Transactions: base.Block.Transactions,
+		Uncles:       base.Block.Uncles,
+	}
+	return &dataset.Entry{
+		Number:       block.Number(),
+		Block:        block,
+		EncodedBlock: block.MarshalRLP(),
+		Trace:        base.Trace,
+		EncodedTrace: base.EncodedTrace,
+	}
+}
+
+// Return the header of the looped block at the given position.
+// Its number and timestamp are shifted by a whole dataset span per lap, and its parent hash is the hash
+// of the block at the previous position.
+func (c *Chain) loopHeader(index *dataset.Index, position int) *types.Header {
+	lap := uint64(position / index.Len())
+	header := index.At(position % index.Len()).Block.Header.Copy()
+	header.Number += lap * numberSpan(index)
+	header.Timestamp += lap * timestampSpan(index)
+	header.ParentHash = c.hash(index, position-1)
+	return header.ComputeHash()
+}
+
+// Return the hash of the block at the given position.
+// The hashes of the looped blocks are cached, since each one depends on all the previous ones.
+func (c *Chain) hash(index *dataset.Index, position int) types.Hash {
+	if position < index.Len() {
+		return index.At(position).Block.Hash()
+	}
+
+	if c.loopIndex != index {
+		c.loopIndex = index
+		c.loopHashes = nil
+	}
+	for len(c.loopHashes) <= position-index.Len() {
+		header := c.loopHeader(index, index.Len()+len(c.loopHashes))
+		c.loopHashes = append(c.loopHashes, header.Hash)
+	}
+	return c.loopHashes[position-index.Len()]
+}
+
+// Return the number of heights covered by the dataset.
+func numberSpan(index *dataset.Index) uint64 {
+	return index.At(index.Len()-1).Number - index.At(0).Number + 1
+}
+
+// Return the time covered by the dataset, plus the average gap between two blocks so that the first block
+// of a lap comes after the last block of the previous one.
+func timestampSpan(index *dataset.Index) uint64 {
+	first, last := index.At(0).Block.Header.Timestamp, index.At(index.Len()-1).Block.Header.Timestamp
+	if index.Len() < 2 || last <= first {
+		return 1
+	}
+	duration := last - first
+	return duration + duration/uint64(index.Len()-1)
+}
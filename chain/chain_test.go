@@ -0,0 +1,143 @@
+package chain
+
+import (
+	"errors"
+	"testing"
+	"zero-provers/server/dataset"
+)
+
+// Small dataset of four blocks, numbered #57, #59, #75 and #77.
+const testArchive = "../data/archives/mock-sstore-and-sha3.tar.bz2"
+
+func newTestChain(t *testing.T, onEnd EndAction) (*Chain, *dataset.Index) {
+	t.Helper()
+	store, err := dataset.NewStore(dataset.Config{Archive: testArchive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(Config{Dataset: store, OnDatasetEnd: onEnd}), store.Index()
+}
+
+func isDone(c *Chain) bool {
+	select {
+	case <-c.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// TestDatasetEnd checks where the head goes once it moves past the last block of the dataset.
+func TestDatasetEnd(t *testing.T) {
+	for _, test := range []struct {
+		onEnd EndAction
+		done  bool
+	}{
+		{HoldAction, false},
+		{ExitAction, true},
+	} {
+		t.Run(string(test.onEnd), func(t *testing.T) {
+			c, index := newTestChain(t, test.onEnd)
+			last := index.At(index.Len() - 1)
+
+			if head := c.Advance(index.Len() - 2); head.Number == last.Number || isDone(c) {
+				t.Fatalf("head on block #%d before the end of the dataset", head.Number)
+			}
+			if head := c.Advance(1); head.Number != last.Number || isDone(c) {
+				t.Fatalf("head on block #%d instead of the last block #%d", head.Number, last.Number)
+			}
+			if head := c.Advance(3); head.Number != last.Number {
+				t.Errorf("head moved to block #%d past the last block #%d", head.Number, last.Number)
+			}
+			if isDone(c) != test.done {
+				t.Errorf("done is %t past the end of the dataset", isDone(c))
+			}
+			if _, ok := c.Get(last.Number + 1); ok {
+				t.Errorf("block #%d found past the end of the dataset", last.Number+1)
+			}
+			if _, err := c.SetHead(last.Number + 1); err == nil {
+				t.Errorf("head moved to block #%d past the end of the dataset", last.Number+1)
+			}
+		})
+	}
+
+	t.Run(string(LoopAction), func(t *testing.T) {
+		c, index := newTestChain(t, LoopAction)
+		head := c.Advance(index.Len())
+		if first := index.At(0); head.Number != first.Number+numberSpan(index) {
+			t.Errorf("head on block #%d instead of block #%d renumbered", head.Number, first.Number)
+		}
+		if isDone(c) {
+			t.Error("done past the end of the dataset")
+		}
+	})
+}
+
+// TestLoopedBlocks checks that the looped blocks are renumbered, re-linked and re-timed over two laps, so that
+// the chain stays consistent.
+func TestLoopedBlocks(t *testing.T) {
+	c, index := newTestChain(t, LoopAction)
+	length := index.Len()
+	c.SetPosition(3*length - 1)
+
+	previous := index.At(0)
+	for position := 1; position < 3*length; position++ {
+		entry := c.entry(index, position)
+		base := index.At(position % length)
+		lap := uint64(position / length)
+
+		if expected := base.Number + lap*numberSpan(index); entry.Number != expected {
+			t.Errorf("block at position %d numbered #%d instead of #%d", position, entry.Number, expected)
+		}
+		// The blocks of the dataset itself aren't necessarily contiguous.
+		if position >= length && entry.Block.ParentHash() != previous.Block.Hash() {
+			t.Errorf("block #%d has parent %s instead of %s", entry.Number, entry.Block.ParentHash(),
+				previous.Block.Hash())
+		}
+		if entry.Block.Header.Timestamp <= previous.Block.Header.Timestamp {
+			t.Errorf("block #%d has timestamp %d, not after %d", entry.Number, entry.Block.Header.Timestamp,
+				previous.Block.Header.Timestamp)
+		}
+		if hash := entry.Block.Header.Copy().ComputeHash().Hash; hash != entry.Block.Hash() {
+			t.Errorf("block #%d has hash %s but hashes to %s", entry.Number, entry.Block.Hash(), hash)
+		}
+		if len(entry.Block.Transactions) != len(base.Block.Transactions) || entry.Trace != base.Trace {
+			t.Errorf("block #%d isn't a copy of block #%d", entry.Number, base.Number)
+		}
+
+		if got, ok := c.Get(entry.Number); !ok || got.Block.Hash() != entry.Block.Hash() {
+			t.Errorf("block #%d not served as built", entry.Number)
+		}
+		if number := entry.Number - 1; number != previous.Number {
+			if _, ok := c.Get(number); ok {
+				t.Errorf("block #%d found in a gap of the dataset", number)
+			}
+		}
+		previous = entry
+	}
+	if _, ok := c.Get(previous.Number + 1); ok {
+		t.Errorf("block #%d found past the head", previous.Number+1)
+	}
+}
+
+// TestLoopLead checks that the head moves at most `maxLoopLead` blocks past the head or the end of the dataset
+// at once in loop mode.
+func TestLoopLead(t *testing.T) {
+	c, index := newTestChain(t, LoopAction)
+	limit := index.Len() - 1 + maxLoopLead
+	ahead := c.header(index, limit+1)
+
+	if _, err := c.SetHead(ahead.Number); !errors.Is(err, ErrTooFarAhead) {
+		t.Errorf("moved to block #%d too far ahead, error %v", ahead.Number, err)
+	}
+	if head := c.Head(); head.Number != index.At(0).Number {
+		t.Errorf("head moved to block #%d on error", head.Number)
+	}
+
+	if head := c.SetPosition(limit + 5); head.Number != c.header(index, limit).Number {
+		t.Errorf("head moved to block #%d instead of block #%d", head.Number, c.header(index, limit).Number)
+	}
+	if head, err := c.SetHead(ahead.Number); err != nil || head.Number != ahead.Number {
+		t.Errorf("head not moved to block #%d once close enough: %v", ahead.Number, err)
+	}
+}
@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Number of events buffered for each subscriber. Events are dropped when a subscriber falls behind.
+const subscriberBufferSize = 64
+
+// Event describes a move of the head of the chain, like edge blockchain events.
+// When the head moves forward, the new blocks are added. When it moves backward, the blocks after the
+// new head are removed, from the highest one down, and the new head is added.
+type Event struct {
+	Added   []Header
+	Removed []Header
+}
+
+// Header identifies a block of the chain.
+type Header struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// Feed dispatches head events to subscribers.
+// The zero value is ready to use.
+type Feed struct {
+	subscribers map[chan Event]struct{}
+	lock        sync.Mutex
+}
+
+// Subscribe returns a channel receiving the events published from now on, along with a function to
+// unsubscribe, which must be called once the events aren't consumed anymore.
+func (f *Feed) Subscribe() (<-chan Event, func()) {
+	events := make(chan Event, subscriberBufferSize)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.subscribers == nil {
+		f.subscribers = make(map[chan Event]struct{})
+	}
+	f.subscribers[events] = struct{}{}
+
+	return events, func() {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		delete(f.subscribers, events)
+	}
+}
+
+// Publish sends an event to every subscriber without blocking.
+func (f *Feed) Publish(event Event) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for events := range f.subscribers {
+		select {
+		case events <- event:
+		default:
+			log.Warn().Msg("Subscriber is too slow, dropping blockchain event")
+		}
+	}
+}
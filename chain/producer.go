@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"time"
+	"zero-provers/server/dataset"
+)
+
+// Interval at which the dataset is checked for new blocks once the replay reaches its end.
+const replayPollInterval = time.Second
+
+// Produce moves the head to the next block every block time, like an edge node sealing blocks.
+// It never returns.
+func (c *Chain) Produce(blockTime time.Duration) {
+	ticker := time.NewTicker(blockTime)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.Advance(1)
+	}
+}
+
+// Replay moves the head to the next block following the gaps between the block timestamps, divided by
+// the given speed, to reproduce the block cadence of the network the dataset was captured from.
+// It never returns, new blocks are picked up if the dataset is reloaded once the replay reaches its end, except
+// in exit mode where the replay ends along with the dataset.
+func (c *Chain) Replay(speed float64) {
+	for {
+		head, next := c.peek()
+		if next == nil {
+			time.Sleep(replayPollInterval)
+			if _, next := c.peek(); next == nil && c.onEnd == ExitAction {
+				// Move past the last block to close the `Done` channel.
+				c.Advance(1)
+				return
+			}
+			continue
+		}
+
+		delay := replayDelay(head, next, speed)
+		log.Debug().Msgf("Next block #%d in %s", next.Number, delay)
+		time.Sleep(delay)
+		c.Advance(1)
+	}
+}
+
+// Compute the time to wait before moving the head from a block to the next one.
+func replayDelay(head, next *dataset.Entry, speed float64) time.Duration {
+	from, to := head.Block.Header.Timestamp, next.Block.Header.Timestamp
+	if to <= from {
+		return 0
+	}
+	return time.Duration(float64(time.Duration(to-from)*time.Second) / speed)
+}
@@ -0,0 +1,139 @@
+package dataset
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Names of the archive directories holding the block and trace files.
+const (
+	archiveBlockDir = "blocks"
+	archiveTraceDir = "traces"
+)
+
+// Read the block and trace files of a dataset archive, sorted in natural order.
+// Archives follow the `<name>/blocks/*` and `<name>/traces/*.json` layout and are read in memory,
+// nothing is extracted to the disk. Supported formats are `.tar.bz2`, `.tar.gz` and `.zip`.
+func readArchive(archivePath string) (blockFiles, traceFiles []file, err error) {
+	var files []file
+	switch {
+	case strings.HasSuffix(archivePath, ".tar.bz2") || strings.HasSuffix(archivePath, ".tbz2"):
+		files, err = readTarArchive(archivePath, func(r io.Reader) (io.Reader, error) {
+			return bzip2.NewReader(r), nil
+		})
+	case strings.HasSuffix(archivePath, ".tar.gz") || strings.HasSuffix(archivePath, ".tgz"):
+		files, err = readTarArchive(archivePath, func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		})
+	case strings.HasSuffix(archivePath, ".zip"):
+		files, err = readZipArchive(archivePath)
+	default:
+		return nil, nil, fmt.Errorf("unsupported archive format: %s", archivePath)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading archive %s: %w", archivePath, err)
+	}
+
+	for _, f := range files {
+		// Only keep block and trace files and skip the resource forks macOS adds to the archives it creates.
+		name := strings.TrimPrefix(f.name, archivePath+":")
+		if strings.HasPrefix(path.Base(name), "._") {
+			continue
+		}
+		switch dir := path.Base(path.Dir(name)); {
+		case dir == archiveBlockDir && slices.Contains(blockFileExtensions, path.Ext(name)):
+			blockFiles = append(blockFiles, f)
+		case dir == archiveTraceDir && slices.Contains(traceFileExtensions, path.Ext(name)):
+			traceFiles = append(traceFiles, f)
+		}
+	}
+
+	if len(blockFiles) == 0 || len(traceFiles) == 0 {
+		return nil, nil, fmt.Errorf("no block or trace files found in archive %s, expected the <name>/%s/* and "+
+			"<name>/%s/*.json layout", archivePath, archiveBlockDir, archiveTraceDir)
+	}
+
+	for _, files := range [][]file{blockFiles, traceFiles} {
+		sort.Slice(files, func(i, j int) bool {
+			return naturalSort(files[i].name, files[j].name)
+		})
+	}
+	return blockFiles, traceFiles, nil
+}
+
+// Read the regular files of a tar archive, decompressed using the given reader.
+func readTarArchive(archivePath string, decompress func(io.Reader) (io.Reader, error)) ([]file, error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	r, err := decompress(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []file
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file{name: archivePath + ":" + header.Name, data: data})
+	}
+	return files, nil
+}
+
+// Read the regular files of a zip archive.
+func readZipArchive(archivePath string) ([]file, error) {
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []file
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file{name: archivePath + ":" + zf.Name, data: content})
+	}
+	return files, nil
+}
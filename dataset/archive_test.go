@@ -0,0 +1,193 @@
+package dataset
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Small archive of four blocks, numbered #57, #59, #75 and #77.
+const testArchive = archivesDir + "/mock-sstore-and-sha3.tar.bz2"
+
+// Files of an archive, by name relative to the root of the archive.
+type archiveFiles map[string][]byte
+
+// Return the files of the test archive, along with files that aren't part of the dataset: a readme, a file
+// of an unsupported extension and a macOS resource fork.
+func testArchiveFiles(t *testing.T) archiveFiles {
+	t.Helper()
+	blockFiles, traceFiles, err := readArchive(testArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(archiveFiles)
+	for _, f := range append(blockFiles, traceFiles...) {
+		files[strings.TrimPrefix(f.name, testArchive+":")] = f.data
+	}
+	files["mock-sstore-and-sha3/README.md"] = []byte("# Mock data")
+	files["mock-sstore-and-sha3/blocks/notes.txt"] = []byte("notes")
+	files["mock-sstore-and-sha3/blocks/._block-57.json"] = []byte{0, 5, 22, 7}
+	return files
+}
+
+// Write the files to an archive of the given format in a temporary directory, and return its path.
+// The archive is built in memory, `.tar.gz` and `.zip` formats only.
+func writeArchive(t *testing.T, files archiveFiles, format string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	switch format {
+	case ".tar.gz":
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		for name, data := range files {
+			header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+			if err := tw.WriteHeader(header); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write(data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := gw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	case ".zip":
+		zw := zip.NewWriter(&buf)
+		for name, data := range files {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	default:
+		t.Fatalf("unsupported archive format %s", format)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "dataset"+format)
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return archivePath
+}
+
+// TestReadArchive checks that the block and trace files of the archives of every supported format are read in
+// natural order, and that the other files are left out.
+func TestReadArchive(t *testing.T) {
+	files := testArchiveFiles(t)
+	for _, format := range []string{".tar.bz2", ".tar.gz", ".zip"} {
+		t.Run(format, func(t *testing.T) {
+			archivePath := testArchive
+			if format != ".tar.bz2" {
+				archivePath = writeArchive(t, files, format)
+			}
+			blockFiles, traceFiles, err := readArchive(archivePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, test := range []struct {
+				files    []file
+				dir      string
+				expected []string
+			}{
+				{blockFiles, "blocks", []string{"block-57.json", "block-59.json", "block-75.json", "block-77.json"}},
+				{traceFiles, "traces", []string{"trace-57.json", "trace-59.json", "trace-75.json", "trace-77.json"}},
+			} {
+				var names []string
+				for _, f := range test.files {
+					names = append(names, path.Base(f.name))
+					name := "mock-sstore-and-sha3/" + test.dir + "/" + path.Base(f.name)
+					if f.name != archivePath+":"+name || !bytes.Equal(f.data, files[name]) {
+						t.Errorf("%s not read as %s", f.name, name)
+					}
+				}
+				if strings.Join(names, " ") != strings.Join(test.expected, " ") {
+					t.Errorf("read %v instead of %v", names, test.expected)
+				}
+			}
+
+			store, err := NewStore(Config{Archive: archivePath})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if index := store.Index(); index.Len() != 4 || index.At(0).Number != 57 || index.At(3).Number != 77 {
+				t.Errorf("%d blocks loaded from #%d", index.Len(), index.At(0).Number)
+			}
+		})
+	}
+}
+
+// TestReadArchiveLayout checks that archives which don't follow the `<name>/blocks` and `<name>/traces` layout,
+// or whose format isn't supported, are rejected.
+func TestReadArchiveLayout(t *testing.T) {
+	files := testArchiveFiles(t)
+	for _, test := range []struct {
+		name   string
+		rename func(name string) string
+		format string
+		err    string
+	}{
+		{
+			"files at the root of the dataset",
+			func(name string) string {
+				return strings.NewReplacer("/blocks/", "/", "/traces/", "/").Replace(name)
+			},
+			".zip",
+			"expected the <name>/blocks/* and <name>/traces/*.json layout",
+		},
+		{
+			"misnamed directories",
+			func(name string) string {
+				return strings.NewReplacer("/blocks/", "/block/", "/traces/", "/trace/").Replace(name)
+			},
+			".tar.gz",
+			"expected the <name>/blocks/* and <name>/traces/*.json layout",
+		},
+		{
+			"traces missing",
+			func(name string) string {
+				if strings.Contains(name, "/traces/") {
+					return ""
+				}
+				return name
+			},
+			".tar.gz",
+			"no block or trace files found",
+		},
+		{"unsupported format", func(name string) string { return name }, ".rar", "unsupported archive format"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			renamed := make(archiveFiles)
+			for name, data := range files {
+				if name := test.rename(name); name != "" {
+					renamed[name] = data
+				}
+			}
+
+			// The format is rejected before the archive is opened.
+			archivePath := filepath.Join(t.TempDir(), "dataset"+test.format)
+			if test.format != ".rar" {
+				archivePath = writeArchive(t, renamed, test.format)
+			}
+
+			if _, _, err := readArchive(archivePath); err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
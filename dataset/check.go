@@ -0,0 +1,77 @@
+package dataset
+
+import (
+	"fmt"
+	"strings"
+	"zero-provers/server/grpc/edge"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Report lists the inconsistencies found between a block of the dataset and its trace.
+type Report struct {
+	Number    uint64
+	BlockFile string
+	TraceFile string
+	Errors    []error
+}
+
+// String formats the report on a line per inconsistency, below a line describing the block.
+func (r Report) String() string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "Block #%d (%s, %s): ", r.Number, r.BlockFile, r.TraceFile)
+	if len(r.Errors) == 0 {
+		report.WriteString("ok")
+	} else {
+		report.WriteString("inconsistent")
+	}
+	for _, err := range r.Errors {
+		fmt.Fprintf(&report, "\n- %v", err)
+	}
+	return report.String()
+}
+
+// Check loads the mock data described by the config, and checks each block and its trace, without rejecting
+// inconsistent blocks and traces unless the config is strict. A report is returned for every block, sorted by
+// ascending number.
+func Check(config Config) ([]Report, error) {
+	setupLogger(config.LogLevel)
+	index, err := readIndex(config)
+	if err != nil {
+		return nil, err
+	}
+	return index.Check(), nil
+}
+
+// Check checks the hashes and the transactions root of each block of the index against its content, each trace
+// against its block, and the parent state root of the trace against the state root of the previous block, when it
+// is part of the index. The witness of each trace is verified as well, every path of the tries it is missing is
+// reported. A report is returned for every block, sorted by ascending number.
+func (i *Index) Check() []Report {
+	reports := make([]Report, 0, len(i.numbers))
+	for _, number := range i.numbers {
+		entry := i.entries[number]
+		var parent *types.Header
+		if previous, ok := i.entries[number-1]; ok {
+			parent = previous.Block.Header
+		}
+		errs := edge.ValidateBlock(entry.Block)
+		errs = append(errs, edge.CheckTrace(entry.Block, entry.Trace, parent)...)
+
+		missingPaths, err := edge.VerifyWitness(entry.Trace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to verify the witness: %w", err))
+		}
+		for _, missingPath := range missingPaths {
+			errs = append(errs, fmt.Errorf("incomplete witness, %s", missingPath))
+		}
+
+		reports = append(reports, Report{
+			Number:    number,
+			BlockFile: entry.BlockFile,
+			TraceFile: entry.TraceFile,
+			Errors:    errs,
+		})
+	}
+	return reports
+}
@@ -0,0 +1,40 @@
+package dataset
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCheckReportsBlockDefects checks that the report of the `check` command lists the blocks that don't match
+// their content, and only those, when the blocks aren't rejected at load time.
+func TestCheckReportsBlockDefects(t *testing.T) {
+	const name = "mock-uniswap-snowball-2.tar.bz2"
+	reports, err := Check(Config{Archive: filepath.Join(archivesDir, name)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inconsistent := 0
+	for _, report := range reports {
+		field, defective := archiveDefects[name][path.Base(report.BlockFile)]
+		if !defective {
+			if len(report.Errors) > 0 {
+				t.Errorf("unexpected report: %s", report)
+			}
+			continue
+		}
+		inconsistent++
+		if len(report.Errors) != 1 || !strings.HasPrefix(report.Errors[0].Error(), field+" ") {
+			t.Errorf("expected a mismatching %s: %s", field, report)
+		}
+	}
+	if inconsistent != len(archiveDefects[name]) {
+		t.Errorf("%d defective blocks reported instead of %d", inconsistent, len(archiveDefects[name]))
+	}
+
+	if _, err := Check(Config{Archive: filepath.Join(archivesDir, name), Strict: true}); err == nil {
+		t.Error("defective blocks loaded in strict mode")
+	}
+}
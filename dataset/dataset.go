@@ -0,0 +1,358 @@
+// Package dataset provides an in-memory store of the mock block and trace data served by the gRPC server.
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"zero-provers/server/logger"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/rs/zerolog"
+)
+
+// Log is the package-level variable used for logging messages and errors.
+var log zerolog.Logger
+
+var (
+	// Extensions of the block files: JSON files in the edge RPC format or holding an edge gRPC response, RLP
+	// encoded blocks, hex dumps of those, and edge gRPC responses encoded using protobuf.
+	blockFileExtensions = []string{".json", ".rlp", ".hex", ".pb"}
+	// Extensions of the trace files.
+	traceFileExtensions = []string{".json"}
+)
+
+// Config contains the locations of the mock data loaded in the store.
+type Config struct {
+	LogLevel zerolog.Level
+
+	// Mock data files, used in static mode.
+	// When set, the store only holds this block and trace pair.
+	BlockFile string
+	TraceFile string
+
+	// Mock data directories, used in dynamic mode.
+	BlockDir string
+	TraceDir string
+
+	// Dataset archive, used in dynamic mode instead of the mock data directories.
+	Archive string
+
+	// Watch the mock data files and reload the store when they change.
+	Watch bool
+
+	// Refuse to load block files that fail to parse or whose hashes don't match their content, and traces that
+	// don't match their block, instead of skipping or logging them.
+	Strict bool
+}
+
+// Entry holds a block of the dataset along with its trace.
+// Both are pre-serialized so that the gRPC handlers only have to do a lookup.
+type Entry struct {
+	Number uint64
+
+	// Names of the files the block and its trace were loaded from.
+	BlockFile string
+	TraceFile string
+
+	Block        *types.Block
+	EncodedBlock []byte // RLP encoding of the block.
+
+	Trace        *types.Trace
+	EncodedTrace []byte // JSON encoding of the trace.
+}
+
+// Index holds the dataset entries indexed by block number.
+type Index struct {
+	entries map[uint64]*Entry
+	// Block numbers of the entries, sorted in ascending order.
+	numbers []uint64
+}
+
+// Store holds the dataset index loaded in memory.
+type Store struct {
+	index *Index
+	lock  sync.RWMutex
+}
+
+// A mock data file read from the disk or from an archive.
+type file struct {
+	name string
+	data []byte
+}
+
+// NewStore loads the mock data described by the config and indexes it by block number.
+func NewStore(config Config) (*Store, error) {
+	setupLogger(config.LogLevel)
+	index, err := loadIndex(config)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Msgf("Dataset loaded with %d blocks", len(index.numbers))
+	store := &Store{index: index}
+
+	if config.Watch {
+		if err := store.watch(config); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// Set up the logger of the package.
+func setupLogger(level zerolog.Level) {
+	lc := logger.LoggerConfig{
+		Level:       level,
+		CallerField: "dataset",
+	}
+	log = logger.NewLogger(lc)
+}
+
+// Index returns the current index of the store.
+// The index is never modified once built, it is swapped when the mock data is reloaded.
+func (s *Store) Index() *Index {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.index
+}
+
+// Get returns the entry of the given block number.
+func (s *Store) Get(number uint64) (*Entry, bool) {
+	return s.Index().Get(number)
+}
+
+// Replace the index of the store.
+func (s *Store) swap(index *Index) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.index = index
+}
+
+// Get returns the entry of the given block number.
+func (i *Index) Get(number uint64) (*Entry, bool) {
+	entry, ok := i.entries[number]
+	return entry, ok
+}
+
+// At returns the entry at the given position, blocks being sorted by ascending number.
+func (i *Index) At(position int) *Entry {
+	return i.entries[i.numbers[position]]
+}
+
+// Position returns the position of the given block number, blocks being sorted by ascending number.
+func (i *Index) Position(number uint64) (int, bool) {
+	position := sort.Search(len(i.numbers), func(j int) bool {
+		return i.numbers[j] >= number
+	})
+	return position, position < len(i.numbers) && i.numbers[position] == number
+}
+
+// Len returns the number of entries in the index.
+func (i *Index) Len() int {
+	return len(i.numbers)
+}
+
+// Load the index from the mock data files, archive or directories, and check the traces against their blocks.
+// Inconsistent traces are logged, or rejected in strict mode.
+func loadIndex(config Config) (*Index, error) {
+	index, err := readIndex(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var inconsistencies []string
+	for _, report := range index.Check() {
+		for _, err := range report.Errors {
+			if config.Strict {
+				inconsistencies = append(inconsistencies, fmt.Sprintf("%s: %v", report.TraceFile, err))
+			} else {
+				log.Warn().Err(err).Msgf("Mock trace file %s doesn't match block #%d", report.TraceFile, report.Number)
+			}
+		}
+	}
+	if len(inconsistencies) > 0 {
+		return nil, fmt.Errorf("inconsistent mock trace files (%d):\n- %s",
+			len(inconsistencies), strings.Join(inconsistencies, "\n- "))
+	}
+	return index, nil
+}
+
+// Read the index from the mock data files, archive or directories.
+func readIndex(config Config) (*Index, error) {
+	var blockFiles, traceFiles []file
+	switch {
+	case config.BlockFile != "" || config.TraceFile != "":
+		blockFile, err := readFile(config.BlockFile)
+		if err != nil {
+			return nil, err
+		}
+		traceFile, err := readFile(config.TraceFile)
+		if err != nil {
+			return nil, err
+		}
+
+		blockFiles := []file{blockFile}
+		if uncleFile, err := readFile(unclesFile(config.BlockFile)); err == nil {
+			blockFiles = append(blockFiles, uncleFile)
+		}
+
+		// The block and trace files are explicitly paired by the user.
+		blocks, _, err := decodeBlocks(blockFiles, config.Strict)
+		if err != nil {
+			return nil, err
+		}
+		traces, _ := decodeTraces([]file{traceFile})
+		if len(blocks) == 0 || len(traces) == 0 {
+			return nil, fmt.Errorf("no valid block and trace pair found")
+		}
+		return buildIndex([]pair{{block: blocks[0], trace: traces[0]}})
+
+	case config.Archive != "":
+		var err error
+		blockFiles, traceFiles, err = readArchive(config.Archive)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		var err error
+		blockFiles, err = readDir(config.BlockDir, blockFileExtensions)
+		if err != nil {
+			return nil, err
+		}
+		traceFiles, err = readDir(config.TraceDir, traceFileExtensions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blocks, skippedBlocks, err := decodeBlocks(blockFiles, config.Strict)
+	if err != nil {
+		return nil, err
+	}
+	traces, skippedTraces := decodeTraces(traceFiles)
+	pairs, err := pairByNumber(blocks, traces, skippedBlocks, skippedTraces)
+	if err != nil {
+		return nil, err
+	}
+	return buildIndex(pairs)
+}
+
+// Build the index out of paired blocks and traces.
+func buildIndex(pairs []pair) (*Index, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no valid block and trace pair found")
+	}
+
+	index := &Index{
+		entries: make(map[uint64]*Entry, len(pairs)),
+		numbers: make([]uint64, 0, len(pairs)),
+	}
+	for _, p := range pairs {
+		entry, err := newEntry(p)
+		if err != nil {
+			return nil, err
+		}
+		index.entries[entry.Number] = entry
+		index.numbers = append(index.numbers, entry.Number)
+	}
+	sort.Slice(index.numbers, func(i, j int) bool {
+		return index.numbers[i] < index.numbers[j]
+	})
+	return index, nil
+}
+
+// Pre-serialize a block and its trace.
+func newEntry(p pair) (*Entry, error) {
+	// Re-encode the trace instead of serving the file content as is, to get rid of any unknown field.
+	encodedTrace, err := json.Marshal(p.trace.trace)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding mock trace %s: %w", p.trace.name, err)
+	}
+
+	block := p.block.block
+	log.Debug().Msgf("Mock data loaded for block #%d from %s and %s", block.Number(), p.block.name, p.trace.name)
+	return &Entry{
+		Number:       block.Number(),
+		BlockFile:    p.block.name,
+		TraceFile:    p.trace.name,
+		Block:        block,
+		EncodedBlock: block.MarshalRLP(),
+		Trace:        p.trace.trace,
+		EncodedTrace: encodedTrace,
+	}, nil
+}
+
+// Read a mock data file.
+func readFile(filePath string) (file, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return file{}, fmt.Errorf("error reading mock file: %w", err)
+	}
+	return file{name: filePath, data: data}, nil
+}
+
+// Return the name of the uncle file of a block file, whatever its format: `block_1.uncles.json` for
+// `block_1.json` as well as for `block_1.rlp`.
+func unclesFile(blockFile string) string {
+	return strings.TrimSuffix(blockFile, filepath.Ext(blockFile)) + unclesFileSuffix
+}
+
+// Read the files of a directory with the given extensions, sorted in natural order.
+func readDir(dirPath string, extensions []string) ([]file, error) {
+	var paths []string
+	for _, extension := range extensions {
+		matches, err := filepath.Glob(filepath.Join(dirPath, "*"+extension))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return naturalSort(paths[i], paths[j])
+	})
+
+	files := make([]file, 0, len(paths))
+	for _, path := range paths {
+		f, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// Sort files in natural order.
+func naturalSort(s1, s2 string) bool {
+	parts1 := strings.FieldsFunc(s1, func(r rune) bool {
+		return !((r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'))
+	})
+	parts2 := strings.FieldsFunc(s2, func(r rune) bool {
+		return !((r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'))
+	})
+
+	for i := 0; i < len(parts1) && i < len(parts2); i++ {
+		part1, part2 := parts1[i], parts2[i]
+		if part1 != part2 {
+			isDigit1 := part1[0] >= '0' && part1[0] <= '9'
+			isDigit2 := part2[0] >= '0' && part2[0] <= '9'
+
+			if isDigit1 && isDigit2 {
+				num1, _ := strconv.Atoi(part1)
+				num2, _ := strconv.Atoi(part2)
+				return num1 < num2
+			}
+
+			return part1 < part2
+		}
+	}
+
+	return len(parts1) < len(parts2)
+}
@@ -0,0 +1,349 @@
+package dataset
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"zero-provers/server/grpc/edge"
+	pb "zero-provers/server/grpc/pb"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"google.golang.org/protobuf/proto"
+)
+
+// Suffix of the uncle files, e.g. `block_150.uncles.json` holds the uncle headers of `block_150.json`.
+const unclesFileSuffix = ".uncles.json"
+
+// Match the last number of a file name, e.g. `150` in `trace_150.json`.
+var fileNumberRegexp = regexp.MustCompile(`(\d+)\D*$`)
+
+// A block file decoded in the gRPC format.
+type blockFile struct {
+	file
+	block *types.Block
+}
+
+// A trace file decoded.
+type traceFile struct {
+	file
+	trace *types.Trace
+}
+
+// A block paired with its trace.
+type pair struct {
+	block blockFile
+	trace traceFile
+}
+
+// Decode block files, and check that the hashes and roots of each block match its content.
+// Uncle files, holding the uncle headers of the block file they are named after, are attached to it. Block
+// files encoded using RLP hold their uncles already, they are rejected when an uncle file is named after them.
+// Files that fail to parse, or whose uncles don't match their header, are logged and skipped, and blocks whose
+// hashes don't match are logged. The skipped files are returned along with the blocks, indexed by the number
+// in their name, so that their traces can be skipped too. In strict mode, an error listing every invalid file
+// is returned instead.
+func decodeBlocks(files []file, strict bool) ([]blockFile, map[uint64]string, error) {
+	uncleFiles := make(map[string]file)
+	for _, f := range files {
+		if strings.HasSuffix(f.name, unclesFileSuffix) {
+			uncleFiles[f.name] = f
+		}
+	}
+
+	blocks := make([]blockFile, 0, len(files))
+	skipped := make(map[uint64]string)
+	var invalid []string
+	for _, f := range files {
+		if strings.HasSuffix(f.name, unclesFileSuffix) {
+			continue
+		}
+		uncleFile, ok := uncleFiles[unclesFile(f.name)]
+		block, err := decodeBlock(f, uncleFile, ok)
+		if err != nil {
+			if strict {
+				invalid = append(invalid, fmt.Sprintf("%s: %v", f.name, err))
+			} else {
+				log.Warn().Err(err).Msgf("Skipping mock block file %s", f.name)
+				skipFile(skipped, f)
+			}
+			continue
+		}
+
+		errs := edge.ValidateBlock(block)
+		for _, err := range errs {
+			if strict {
+				invalid = append(invalid, fmt.Sprintf("%s: %v", f.name, err))
+			} else {
+				log.Warn().Err(err).Msgf("Mock block file %s doesn't match its content", f.name)
+			}
+		}
+		if strict && len(errs) > 0 {
+			continue
+		}
+		blocks = append(blocks, blockFile{file: f, block: block})
+	}
+
+	if len(invalid) > 0 {
+		return nil, nil, fmt.Errorf("invalid mock block files (%d):\n- %s", len(invalid), strings.Join(invalid, "\n- "))
+	}
+	return blocks, skipped, nil
+}
+
+// Decode a block file along with its uncle file, if any, and check its uncles.
+// Blocks encoded using RLP, as returned by the edge gRPC API, are decoded as is, and their uncles are part of
+// their encoding.
+func decodeBlock(f file, uncleFile file, hasUncleFile bool) (*types.Block, error) {
+	encoded, ok, err := encodedBlock(f)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if hasUncleFile {
+			return nil, fmt.Errorf("uncle headers are part of the RLP encoding of the block, not %s", uncleFile.name)
+		}
+		return decodeRLPBlock(encoded)
+	}
+
+	var blockRPC edge.BlockRPC
+	if err := json.Unmarshal(f.data, &blockRPC); err != nil {
+		return nil, err
+	}
+	if hasUncleFile {
+		if len(blockRPC.UncleHeaders) > 0 {
+			return nil, fmt.Errorf("uncle headers are defined in both the block and %s", uncleFile.name)
+		}
+		if err := json.Unmarshal(uncleFile.data, &blockRPC.UncleHeaders); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", uncleFile.name, err)
+		}
+	}
+
+	block, err := blockRPC.ToBlockGrpc()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUncles(block, blockRPC.Uncles); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// Return the RLP encoding of the block held by a block file, depending on its extension:
+//   - `.rlp` files hold the raw encoding;
+//   - `.hex` files hold the encoding in hex, with or without prefix;
+//   - `.pb` files hold an edge gRPC `BlockData` response encoded using protobuf;
+//   - `.json` files may hold an edge gRPC `BlockData` response encoded using JSON, e.g. by `grpcurl`, whose
+//     `data` field is the encoding in base64.
+//
+// False is returned for the other JSON files, which hold a block in the edge RPC format.
+func encodedBlock(f file) ([]byte, bool, error) {
+	switch path.Ext(f.name) {
+	case ".rlp":
+		return f.data, true, nil
+
+	case ".hex":
+		encoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(f.data)), "0x"))
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid hex encoding: %w", err)
+		}
+		return encoded, true, nil
+
+	case ".pb":
+		var response pb.BlockData
+		if err := proto.Unmarshal(f.data, &response); err != nil {
+			return nil, false, fmt.Errorf("invalid gRPC response: %w", err)
+		}
+		return response.Data, true, nil
+
+	default:
+		var response struct {
+			Data []byte `json:"data"`
+		}
+		if err := json.Unmarshal(f.data, &response); err != nil || len(response.Data) == 0 {
+			return nil, false, nil
+		}
+		return response.Data, true, nil
+	}
+}
+
+// Decode a block encoded using RLP and check its uncles.
+func decodeRLPBlock(encoded []byte) (*types.Block, error) {
+	if len(encoded) == 0 {
+		return nil, errors.New("empty block encoding")
+	}
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(encoded); err != nil {
+		return nil, fmt.Errorf("invalid RLP encoding: %w", err)
+	}
+	// Decoding hashes the transactions depending on the forks of edge, rehash them like the other blocks.
+	for _, tx := range block.Transactions {
+		edge.ComputeTxHash(tx)
+	}
+	if err := checkUncles(block, nil); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// Check that the uncles of a block match the uncles root of its header, and the uncle hashes listed by the
+// edge RPC, if any. Otherwise the RLP encoding of the block wouldn't match its header.
+func checkUncles(block *types.Block, hashes []types.Hash) error {
+	if len(hashes) > 0 && len(block.Uncles) == 0 {
+		return fmt.Errorf("the block has %d uncles but no uncle headers", len(hashes))
+	}
+	if len(hashes) > 0 && len(hashes) != len(block.Uncles) {
+		return fmt.Errorf("the block has %d uncles but %d uncle headers", len(hashes), len(block.Uncles))
+	}
+	for i, hash := range hashes {
+		if computed := block.Uncles[i].Copy().ComputeHash().Hash; computed != hash {
+			return fmt.Errorf("uncle #%d hashes to %s instead of %s", i, computed, hash)
+		}
+	}
+	if root := buildroot.CalculateUncleRoot(block.Uncles); root != block.Header.Sha3Uncles {
+		return fmt.Errorf("uncles root %s doesn't match sha3Uncles %s", root, block.Header.Sha3Uncles)
+	}
+	return nil
+}
+
+// Decode trace files. Files that fail to parse are logged and skipped, and returned along with the traces,
+// indexed by the number in their name, so that their blocks can be skipped too.
+func decodeTraces(files []file) ([]traceFile, map[uint64]string) {
+	traces := make([]traceFile, 0, len(files))
+	skipped := make(map[uint64]string)
+	for _, f := range files {
+		var trace types.Trace
+		if err := json.Unmarshal(f.data, &trace); err != nil {
+			log.Warn().Err(err).Msgf("Skipping mock trace file %s", f.name)
+			skipFile(skipped, f)
+			continue
+		}
+		traces = append(traces, traceFile{file: f, trace: &trace})
+	}
+	return traces, skipped
+}
+
+// Record a skipped file under the number in its name, if any.
+func skipFile(skipped map[uint64]string, f file) {
+	if number, ok := fileNumber(f.name); ok {
+		skipped[number] = f.name
+	}
+}
+
+// Pair blocks and traces using the number of the blocks.
+// A trace belongs to block `n` when its parent state root is the state root of block `n-1`. When the
+// parent block isn't part of the dataset, or when several blocks share the same state root (e.g. empty
+// blocks), the trace is paired using the number in its file name instead.
+// Traces of skipped block files, and blocks of skipped trace files, are logged and skipped as well.
+// An error listing every unpaired block and trace is returned if the dataset isn't consistent.
+func pairByNumber(blocks []blockFile, traces []traceFile, skippedBlocks, skippedTraces map[uint64]string) ([]pair, error) {
+	blocksByNumber := make(map[uint64]blockFile, len(blocks))
+	var duplicates []string
+	for _, b := range blocks {
+		number := b.block.Number()
+		if other, ok := blocksByNumber[number]; ok {
+			duplicates = append(duplicates, fmt.Sprintf("block #%d is defined in both %s and %s", number, other.name, b.name))
+			continue
+		}
+		blocksByNumber[number] = b
+	}
+
+	// Index the children of each state root.
+	children := make(map[types.Hash][]uint64)
+	for number, b := range blocksByNumber {
+		if _, ok := blocksByNumber[number+1]; ok {
+			children[b.block.Header.StateRoot] = append(children[b.block.Header.StateRoot], number+1)
+		}
+	}
+
+	pairs := make(map[uint64]pair, len(blocks))
+	var unpairedTraces []string
+	for _, t := range traces {
+		number, ok := traceBlockNumber(t, children)
+		if !ok {
+			unpairedTraces = append(unpairedTraces, fmt.Sprintf("%s (no block number found)", t.name))
+			continue
+		}
+		b, ok := blocksByNumber[number]
+		if name, skipped := skippedBlocks[number]; !ok && skipped {
+			log.Warn().Msgf("Skipping mock trace file %s since its block file %s is invalid", t.name, name)
+			continue
+		}
+		if !ok {
+			unpairedTraces = append(unpairedTraces, fmt.Sprintf("%s (block #%d not found)", t.name, number))
+			continue
+		}
+		if other, ok := pairs[number]; ok {
+			unpairedTraces = append(unpairedTraces, fmt.Sprintf("%s (block #%d already paired with %s)", t.name, number, other.trace.name))
+			continue
+		}
+		pairs[number] = pair{block: b, trace: t}
+	}
+
+	var unpairedBlocks []string
+	for number, b := range blocksByNumber {
+		if _, ok := pairs[number]; ok {
+			continue
+		}
+		if name, skipped := skippedTraces[number]; skipped {
+			log.Warn().Msgf("Skipping mock block file %s since its trace file %s is invalid", b.name, name)
+			continue
+		}
+		unpairedBlocks = append(unpairedBlocks, fmt.Sprintf("#%d (%s)", number, b.name))
+	}
+
+	if len(duplicates) > 0 || len(unpairedBlocks) > 0 || len(unpairedTraces) > 0 {
+		var report strings.Builder
+		report.WriteString("unable to pair blocks and traces")
+		for _, section := range []struct {
+			title string
+			items []string
+		}{
+			{"duplicated blocks", duplicates},
+			{"blocks without trace", unpairedBlocks},
+			{"traces without block", unpairedTraces},
+		} {
+			if len(section.items) == 0 {
+				continue
+			}
+			sort.Slice(section.items, func(i, j int) bool {
+				return naturalSort(section.items[i], section.items[j])
+			})
+			fmt.Fprintf(&report, "\n- %s (%d): %s", section.title, len(section.items), strings.Join(section.items, ", "))
+		}
+		return nil, errors.New(report.String())
+	}
+
+	result := make([]pair, 0, len(pairs))
+	for _, p := range pairs {
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Return the number of the block a trace belongs to, using its parent state root or its file name.
+func traceBlockNumber(t traceFile, children map[types.Hash][]uint64) (uint64, bool) {
+	if numbers := children[t.trace.ParentStateRoot]; len(numbers) == 1 {
+		return numbers[0], true
+	}
+
+	return fileNumber(t.name)
+}
+
+// Return the last number of a file name.
+func fileNumber(name string) (uint64, bool) {
+	match := fileNumberRegexp.FindStringSubmatch(path.Base(name))
+	if match == nil {
+		return 0, false
+	}
+	number, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
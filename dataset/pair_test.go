@@ -0,0 +1,152 @@
+package dataset
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"zero-provers/server/grpc/edge"
+)
+
+// Directory of the dataset archives shipped with the server.
+const archivesDir = "../data/archives"
+
+// Fields of the block files of the archives that don't match their content, by archive and file. These block
+// files record an all-zero `transactionsRoot`, while their transactions still hash as recorded in the files.
+var archiveDefects = map[string]map[string]string{
+	"mock-uniswap-snowball-2.tar.bz2": {
+		"block_144.json": "transactionsRoot",
+		"block_146.json": "transactionsRoot",
+		"block_148.json": "transactionsRoot",
+		"block_149.json": "transactionsRoot",
+		"block_152.json": "transactionsRoot",
+		"block_153.json": "transactionsRoot",
+		"block_154.json": "transactionsRoot",
+		"block_219.json": "transactionsRoot",
+		"block_220.json": "transactionsRoot",
+		"block_245.json": "transactionsRoot",
+		"block_248.json": "transactionsRoot",
+		"block_249.json": "transactionsRoot",
+	},
+}
+
+// Archives whose traces can't be paired with their blocks: the blocks of `ds-9071047` go from #0 to #305 but
+// its traces from #1 to #309.
+var unpairedArchives = map[string]bool{
+	"ds-9071047.tar.bz2": true,
+}
+
+// TestArchivesRoundTrip checks that the transactions of every block of the archives, once converted from the
+// edge RPC format, are encoded so that they hash to the hashes recorded in the block files, and that the blocks
+// match their headers but for the known defects of the datasets.
+func TestArchivesRoundTrip(t *testing.T) {
+	archives, err := filepath.Glob(filepath.Join(archivesDir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) == 0 {
+		t.Fatalf("no archive found in %s", archivesDir)
+	}
+
+	for _, archive := range archives {
+		name := filepath.Base(archive)
+		t.Run(name, func(t *testing.T) {
+			blockFiles, traceFiles, err := readArchive(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			blocks, skippedBlocks, err := decodeBlocks(blockFiles, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(skippedBlocks) > 0 {
+				t.Fatalf("block files failed to parse: %v", skippedBlocks)
+			}
+
+			txs := 0
+			defects := make(map[string]string)
+			for _, b := range blocks {
+				for i, tx := range b.block.Transactions {
+					computed := tx.Copy()
+					edge.ComputeTxHash(computed)
+					if computed.Hash != tx.Hash {
+						t.Errorf("%s: transaction #%d hashes to %s instead of %s", b.name, i, computed.Hash, tx.Hash)
+					}
+					txs++
+				}
+
+				for _, err := range edge.ValidateBlock(b.block) {
+					field, _, _ := strings.Cut(err.Error(), " ")
+					if _, ok := defects[path.Base(b.name)]; ok || archiveDefects[name][path.Base(b.name)] != field {
+						t.Errorf("%s: %v", b.name, err)
+						continue
+					}
+					defects[path.Base(b.name)] = field
+				}
+			}
+			for file, field := range archiveDefects[name] {
+				if defects[file] != field {
+					t.Errorf("%s: expected a mismatching %s", file, field)
+				}
+			}
+			t.Logf("%d blocks and %d transactions checked", len(blocks), txs)
+
+			traces, skippedTraces := decodeTraces(traceFiles)
+			_, err = pairByNumber(blocks, traces, skippedBlocks, skippedTraces)
+			switch {
+			case unpairedArchives[name] && err == nil:
+				t.Error("the traces are paired with the blocks, remove the archive from the unpaired ones")
+			case unpairedArchives[name]:
+				t.Logf("traces not paired as expected: %v", err)
+			case err != nil:
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestUncleFiles checks that uncle files are named after their block file whatever its format, and that they
+// are rejected next to block files encoded using RLP, which hold their uncles already.
+func TestUncleFiles(t *testing.T) {
+	for blockFile, expected := range map[string]string{
+		"data/blocks/block_1.json":                    "data/blocks/block_1.uncles.json",
+		"data/blocks/block_1.rlp":                     "data/blocks/block_1.uncles.json",
+		"data/blocks/block_1.hex":                     "data/blocks/block_1.uncles.json",
+		"dataset.tar.bz2:dataset/blocks/block_1.pb":   "dataset.tar.bz2:dataset/blocks/block_1.uncles.json",
+		"dataset.tar.bz2:dataset/blocks/block_1.json": "dataset.tar.bz2:dataset/blocks/block_1.uncles.json",
+	} {
+		if name := unclesFile(blockFile); name != expected {
+			t.Errorf("uncle file of %s named %s instead of %s", blockFile, name, expected)
+		}
+	}
+
+	blockFiles, _, err := readArchive(testArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, _, err := decodeBlocks(blockFiles[:1], true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks[0].block.Uncles) > 0 {
+		t.Fatalf("%s has uncles", blocks[0].name)
+	}
+	uncles := file{name: "blocks/block_1.uncles.json", data: []byte("[]")}
+
+	jsonBlock := file{name: "blocks/block_1.json", data: blockFiles[0].data}
+	if _, _, err := decodeBlocks([]file{jsonBlock, uncles}, true); err != nil {
+		t.Errorf("JSON block with an uncle file rejected: %v", err)
+	}
+	invalidUncles := file{name: uncles.name, data: []byte("[1]")}
+	if _, _, err := decodeBlocks([]file{jsonBlock, invalidUncles}, true); err == nil {
+		t.Error("invalid uncle file not attached to its JSON block")
+	}
+	rlpBlock := file{name: "blocks/block_1.rlp", data: blocks[0].block.MarshalRLP()}
+	if _, _, err := decodeBlocks([]file{rlpBlock}, true); err != nil {
+		t.Errorf("RLP block rejected: %v", err)
+	}
+	_, _, err = decodeBlocks([]file{rlpBlock, uncles}, true)
+	if expected := "not blocks/block_1.uncles.json"; err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected an error containing %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,84 @@
+package dataset
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Delay during which file events are batched before reloading the store.
+// Editors and copy tools usually trigger several events for a single change.
+const reloadDelay = 500 * time.Millisecond
+
+// Watch the mock data and reload the store whenever the files change.
+// The new index is only swapped in once it has been fully loaded, so requests are never served from a
+// partially loaded dataset. When the reload fails, the previous index is kept.
+func (s *Store) watch(config Config) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch the parent directories of the mock data files since editors often replace files instead of
+	// writing to them, which would silently drop a watch set on the file itself.
+	var dirs []string
+	files := make(map[string]bool)
+	switch {
+	case config.BlockFile != "" || config.TraceFile != "":
+		for _, path := range []string{config.BlockFile, unclesFile(config.BlockFile), config.TraceFile} {
+			files[filepath.Clean(path)] = true
+			dirs = append(dirs, filepath.Dir(path))
+		}
+	case config.Archive != "":
+		files[filepath.Clean(config.Archive)] = true
+		dirs = append(dirs, filepath.Dir(config.Archive))
+	default:
+		dirs = append(dirs, config.BlockDir, config.TraceDir)
+	}
+	for _, dir := range dirs {
+		if err = watcher.Add(dir); err != nil {
+			watcher.Close()
+			return err
+		}
+		log.Debug().Msgf("Watching %s for mock data changes", dir)
+	}
+
+	go func() {
+		defer watcher.Close()
+		reload := time.NewTimer(reloadDelay)
+		reload.Stop()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if len(files) > 0 && !files[filepath.Clean(event.Name)] {
+					continue
+				}
+				if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				log.Debug().Msgf("Mock data change detected: %s", event)
+				reload.Reset(reloadDelay)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error().Err(err).Msg("Unable to watch the mock data")
+
+			case <-reload.C:
+				index, err := loadIndex(config)
+				if err != nil {
+					log.Error().Err(err).Msg("Unable to reload the mock data, keeping the previous dataset")
+					continue
+				}
+				s.swap(index)
+				log.Info().Msgf("Dataset reloaded with %d blocks", len(index.numbers))
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,244 @@
+package edge
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// Number of contracts deployed at genesis, called by the executed transactions.
+	writerContractsAmount = 8
+	// Gas used by a storage write of a writer contract: a cold `SSTORE` on an empty slot, the loop overhead
+	// and the cost of its calldata.
+	storageWriteGas = 23_200
+	// Gas used by the deposit of a byte of deployed code.
+	codeDepositGas = 200
+	// Gas added to the estimated gas of the executed transactions.
+	executionGasMargin = 10_000
+)
+
+// Runtime code of the writer contracts. It stores its calldata, read as a list of key and value pairs:
+//
+//	for offset := 0; offset < calldatasize(); offset += 64 {
+//		sstore(calldataload(offset), calldataload(offset+32))
+//	}
+var writerCode = []byte{
+	0x60, 0x00, // PUSH1 0
+	0x5b,       // JUMPDEST
+	0x80,       // DUP1
+	0x36,       // CALLDATASIZE
+	0x11,       // GT
+	0x15,       // ISZERO
+	0x60, 0x18, // PUSH1 0x18
+	0x57,       // JUMPI
+	0x80,       // DUP1
+	0x60, 0x20, // PUSH1 32
+	0x01,       // ADD
+	0x35,       // CALLDATALOAD
+	0x81,       // DUP2
+	0x35,       // CALLDATALOAD
+	0x55,       // SSTORE
+	0x60, 0x40, // PUSH1 64
+	0x01,       // ADD
+	0x60, 0x02, // PUSH1 0x02
+	0x56, // JUMP
+	0x5b, // JUMPDEST
+	0x00, // STOP
+}
+
+// Executor runs the random transactions through the edge EVM, on top of an in-memory state. The genesis
+// state funds the accounts of the wallet and holds the writer contracts.
+// The trie of the pinned edge version prints every node it looks up to the standard output, there is no way
+// to access the state without it.
+type Executor struct {
+	executor  *state.Executor
+	contracts []types.Address
+}
+
+// NewExecutor returns an executor for the given chain ID, along with the root of its genesis state. The hash
+// function is used by the `BLOCKHASH` opcode.
+func NewExecutor(chainID uint64, wallet *Wallet, getHash func(number uint64) types.Hash) (*Executor, types.Hash, error) {
+	params := &chain.Params{
+		Forks:        chain.AllForksEnabled,
+		ChainID:      int64(chainID),
+		BurnContract: map[uint64]types.Address{0: types.ZeroAddress},
+	}
+	e := &Executor{
+		executor: state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger()),
+	}
+	e.executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return getHash
+	}
+
+	alloc := make(map[types.Address]*chain.GenesisAccount)
+	for _, address := range wallet.Addresses() {
+		alloc[address] = &chain.GenesisAccount{Balance: new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)}
+	}
+	for i := 0; i < writerContractsAmount; i++ {
+		address := types.StringToAddress(fmt.Sprintf("0x%x", 0xc0de0000+i))
+		alloc[address] = &chain.GenesisAccount{Code: writerCode}
+		e.contracts = append(e.contracts, address)
+	}
+
+	root, err := e.executor.WriteGenesis(alloc, types.ZeroHash)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+	return e, root, nil
+}
+
+// GenerateExecutedEdgeBlock generates a random `Block` child of the given parent header, shaped by the given
+// profile and target, whose transactions are signed by the accounts of the wallet and run by the executor on
+// top of the state of the parent. The trace of the block is returned along with it: its tries, state changes
+// and receipts come from the execution, and the state root of the block is the root of the resulting state.
+// The number of transactions is targeted, and the gas used approximately, the sizes of the tries and of the
+// trace aren't since they result from the execution.
+func GenerateExecutedEdgeBlock(parent *types.Header, profile Profile, target Target, wallet *Wallet,
+	executor *Executor) (*types.Block, *types.Trace, error) {
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Miner:      []byte{1, 2, 3},
+		Difficulty: generateRandomBigInt().Uint64(),
+		Number:     parent.Number + 1,
+		Timestamp:  parent.Timestamp + blockTimestampGap,
+		ExtraData:  []byte{4, 5, 6},
+		MixHash:    *generateRandomHash(),
+		Nonce:      types.Nonce{7, 8, 9, 10, 11, 12, 13, 14},
+		BaseFee:    generateRandomBigInt().Uint64(),
+	}
+
+	// Generate a list of random transactions writing storage slots of the writer contracts.
+	txsAmount := 0
+	if !profile.emptyBlock() {
+		txsAmount = generateRandomIntIn(profile.MinTxs, profile.MaxTxs)
+	}
+	if target.Txs > 0 {
+		txsAmount = target.Txs
+	}
+	if target.Gas > 0 && txsAmount == 0 {
+		txsAmount = 1
+	}
+	var transactions []*types.Transaction
+	var gasLimit uint64
+	for i := 0; i < txsAmount; i++ {
+		writes := generateRandomIntIn(profile.MinStorageWrites, profile.MaxStorageWrites)
+		if target.Gas > 0 {
+			writes = int(max(target.Gas/uint64(txsAmount), state.TxGas)-state.TxGas) / storageWriteGas
+		}
+
+		tx, err := generateExecutedTx(profile, writes, new(big.Int).SetUint64(header.BaseFee), executor.contracts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tx, err = wallet.Sign(tx); err != nil {
+			return nil, nil, err
+		}
+		transactions = append(transactions, tx)
+		gasLimit += tx.Gas
+	}
+	header.GasLimit = max(blockGasLimit, gasLimit)
+
+	uncles := generateRandomUncles(parent)
+	header.Sha3Uncles = buildroot.CalculateUncleRoot(uncles)
+
+	// Run the transactions on top of the state of the parent.
+	transition, err := executor.executor.BeginTxn(parent.StateRoot, header, types.BytesToAddress(header.Miner))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, tx := range transactions {
+		if err := transition.Write(tx); err != nil {
+			return nil, nil, fmt.Errorf("unable to execute transaction %s: %w", tx.Hash, err)
+		}
+	}
+	_, trace, root, err := transition.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fill the trace like edge does.
+	receipts := transition.Receipts()
+	trace.ParentStateRoot = parent.StateRoot
+	for i, txnTrace := range trace.TxnTraces {
+		txnTrace.ReceiptRoot = buildroot.CalculateReceiptsRoot(receipts[:i+1])
+	}
+
+	header.StateRoot = root
+	header.TxRoot = CalculateTxRoot(transactions)
+	header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(receipts)
+	header.LogsBloom = types.CreateBloom(receipts)
+	header.GasUsed = transition.TotalGas()
+
+	block := &types.Block{
+		Header:       header.ComputeHash(),
+		Transactions: transactions,
+		Uncles:       uncles,
+	}
+	return block, trace, nil
+}
+
+// Generate an unsigned transaction writing the given number of storage slots of a writer contract, or
+// deploying a new writer contract. A transaction without any storage write is a plain transfer to a new
+// account. Its fees cover the given base fee, and its gas limit covers its execution.
+func generateExecutedTx(profile Profile, writes int, baseFee *big.Int, contracts []types.Address) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		Value: generateRandomBigInt(),
+	}
+	if generateRandomInt(2) == 1 {
+		tx.Type = types.DynamicFeeTx
+		tx.GasTipCap = generateRandomBigInt()
+		tx.GasFeeCap = new(big.Int).Add(baseFee, tx.GasTipCap)
+	} else {
+		tx.Type = types.LegacyTx
+		tx.GasPrice = new(big.Int).Add(baseFee, generateRandomBigInt())
+	}
+
+	var executionGas uint64
+	switch {
+	case profile.deployment():
+		// Pad the runtime code with unreachable random bytes to reach the code size of the profile.
+		size := min(generateRandomIntIn(profile.MinCodeSize, profile.MaxCodeSize), state.SpuriousDragonMaxCodeSize)
+		code := append(append([]byte{}, writerCode...), generateRandomByteSlice(max(size-len(writerCode), 0))...)
+		tx.Input = deploymentCode(code)
+		executionGas = uint64(len(code)) * codeDepositGas
+
+	case writes > 0:
+		tx.To = &contracts[generateRandomInt(int64(len(contracts)))]
+		tx.Input = generateRandomByteSlice(writes * 2 * types.HashLength)
+		executionGas = uint64(writes) * storageWriteGas
+
+	default:
+		tx.To = generateRandomAddress()
+	}
+
+	intrinsicGas, err := state.TransactionGasCost(tx, true, true)
+	if err != nil {
+		return nil, err
+	}
+	tx.Gas = intrinsicGas + executionGas + executionGasMargin
+	return tx, nil
+}
+
+// Return the init code deploying the given runtime code, which follows it:
+//
+//	codecopy(0, initcodesize, len(code))
+//	return(0, len(code))
+func deploymentCode(code []byte) []byte {
+	initCode := []byte{
+		0x61, byte(len(code) >> 8), byte(len(code)), // PUSH2 len(code)
+		0x80,       // DUP1
+		0x60, 0x0c, // PUSH1 12, the size of the init code
+		0x60, 0x00, // PUSH1 0
+		0x39,       // CODECOPY
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	}
+	return append(initCode, code...)
+}
@@ -0,0 +1,174 @@
+package edge
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// Maximum number of blocks generated ahead of the highest block of the chain in a single request.
+	generatorMaxGap = 1024
+
+	// Range of the random timestamp of the parent of the first block, from 2020 to 2023.
+	genesisTimestampMin   = 1_577_836_800
+	genesisTimestampRange = 94_608_000
+
+	// Number of accounts signing the random transactions.
+	walletSize = 16
+)
+
+// ErrOutOfRange is returned when the requested block comes before the first block of the chain, or too far
+// after the highest block generated so far.
+var ErrOutOfRange = errors.New("block out of the range of the random chain")
+
+// GeneratorConfig describes the chain of random blocks.
+type GeneratorConfig struct {
+	// Number of the first block of the chain.
+	First uint64
+	// Chain ID the transactions are signed for.
+	ChainID uint64
+
+	// Profile and target shaping the blocks and traces.
+	Profile Profile
+	Target  Target
+	// Number of consecutive heights the target is stepped through, from 1 to `SweepSteps` times its size,
+	// before starting over. The target is used as is when zero.
+	SweepSteps int
+
+	// Run the transactions through the edge EVM, so that the traces hold real witnesses and state changes,
+	// and the blocks real state roots.
+	Execute bool
+}
+
+// Generator generates a chain of random blocks along with their traces, starting at a given block number.
+// Blocks are generated in order, each one being the child of the previous one, and are kept so that a
+// block and its trace stay the same every time they are requested.
+type Generator struct {
+	config   GeneratorConfig
+	wallet   *Wallet
+	executor *Executor
+
+	// Parent header of the first block of the chain. Only its number, hash, state root and timestamp are set.
+	genesis *types.Header
+
+	// Blocks and traces generated so far, starting at the first block.
+	blocks []*types.Block
+	traces []*types.Trace
+	lock   sync.Mutex
+}
+
+// NewGenerator returns a generator of the chain of random blocks described by the config. The random values
+// are drawn from the source seeded with `SetSeed`.
+func NewGenerator(config GeneratorConfig) (*Generator, error) {
+	g := &Generator{
+		config: config,
+		wallet: NewWallet(config.ChainID, walletSize),
+		genesis: &types.Header{
+			Number:    config.First - 1,
+			Hash:      *generateRandomHash(),
+			StateRoot: *generateRandomHash(),
+			Timestamp: uint64(genesisTimestampMin + generateRandomInt(genesisTimestampRange)),
+		},
+	}
+
+	if config.Execute {
+		var err error
+		g.executor, g.genesis.StateRoot, err = NewExecutor(config.ChainID, g.wallet, g.hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// First returns the number of the first block of the chain.
+func (g *Generator) First() uint64 {
+	return g.config.First
+}
+
+// Genesis returns the parent hash of the first block of the chain.
+func (g *Generator) Genesis() types.Hash {
+	return g.genesis.Hash
+}
+
+// Block returns the random block of the given number, generating it and the blocks before it on the first
+// request. It fails with `ErrOutOfRange` if the block comes before the first block of the chain, or too far
+// after the highest one.
+func (g *Generator) Block(number uint64) (*types.Block, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if err := g.generate(number); err != nil {
+		return nil, err
+	}
+	return g.blocks[number-g.config.First], nil
+}
+
+// Trace returns the trace of the random block of the given number, generating it like `Block` does.
+func (g *Generator) Trace(number uint64) (*types.Trace, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if err := g.generate(number); err != nil {
+		return nil, err
+	}
+	return g.traces[number-g.config.First], nil
+}
+
+// Generate the blocks up to the given number, the lock being held by the caller.
+func (g *Generator) generate(number uint64) error {
+	if number < g.config.First || number-g.config.First >= uint64(len(g.blocks))+generatorMaxGap {
+		return ErrOutOfRange
+	}
+
+	for uint64(len(g.blocks)) <= number-g.config.First {
+		parent := g.genesis
+		if len(g.blocks) > 0 {
+			parent = g.blocks[len(g.blocks)-1].Header
+		}
+
+		block, trace, err := g.generateBlock(parent)
+		if err != nil {
+			return err
+		}
+		g.blocks = append(g.blocks, block)
+		g.traces = append(g.traces, trace)
+	}
+	return nil
+}
+
+// Return the target of the block of the given number, stepped through when sweeping.
+func (g *Generator) target(number uint64) Target {
+	if g.config.SweepSteps <= 0 {
+		return g.config.Target
+	}
+	step := (number - g.config.First) % uint64(g.config.SweepSteps)
+	return g.config.Target.Scale(int(step) + 1)
+}
+
+// Generate the child of the given parent header along with its trace, the lock being held by the caller.
+func (g *Generator) generateBlock(parent *types.Header) (*types.Block, *types.Trace, error) {
+	target := g.target(parent.Number + 1)
+	if g.executor != nil {
+		return GenerateExecutedEdgeBlock(parent, g.config.Profile, target, g.wallet, g.executor)
+	}
+
+	block, receipts, err := GenerateRandomEdgeBlock(parent, g.config.Profile, target, g.wallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, GenerateRandomEdgeTrace(block, receipts, parent.StateRoot, g.config.Profile, target), nil
+}
+
+// Return the hash of the block of the given number, or the zero hash if it isn't generated yet. The lock is
+// held by the caller, the hash being requested while generating a block.
+func (g *Generator) hash(number uint64) types.Hash {
+	switch {
+	case number == g.genesis.Number:
+		return g.genesis.Hash
+	case number >= g.config.First && number-g.config.First < uint64(len(g.blocks)):
+		return g.blocks[number-g.config.First].Hash()
+	default:
+		return types.ZeroHash
+	}
+}
@@ -0,0 +1,147 @@
+package edge
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+// Number of blocks generated by the generator tests.
+const generatedBlocks = 5
+
+// Return a generator of random blocks whose values are drawn from the given seed, running the transactions
+// through the edge EVM or not.
+func newTestGenerator(t *testing.T, seed int64, execute bool) *Generator {
+	t.Helper()
+	SetSeed(seed)
+	generator, err := NewGenerator(GeneratorConfig{
+		First:   1,
+		ChainID: 2001,
+		Profile: Profiles[DefaultProfile],
+		Execute: execute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return generator
+}
+
+// Return the encodings of the blocks and traces of a generator, the way the server sends them.
+func generateEncoded(t *testing.T, generator *Generator) (blocks, traces [][]byte) {
+	t.Helper()
+	for number := uint64(1); number <= generatedBlocks; number++ {
+		block, err := generator.Block(number)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trace, err := generator.Trace(number)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encodedTrace, err := json.Marshal(trace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, block.MarshalRLP())
+		traces = append(traces, encodedTrace)
+	}
+	return blocks, traces
+}
+
+// Name the subtests after the mode the generator runs in.
+func executionName(execute bool) string {
+	if execute {
+		return "random-evm"
+	}
+	return "random"
+}
+
+// TestGeneratorSeed checks that generators seeded alike generate byte-identical blocks and traces.
+func TestGeneratorSeed(t *testing.T) {
+	for _, execute := range []bool{false, true} {
+		t.Run(executionName(execute), func(t *testing.T) {
+			blocks, traces := generateEncoded(t, newTestGenerator(t, 42, execute))
+			sameBlocks, sameTraces := generateEncoded(t, newTestGenerator(t, 42, execute))
+			otherBlocks, _ := generateEncoded(t, newTestGenerator(t, 43, execute))
+
+			for i := range blocks {
+				if !bytes.Equal(blocks[i], sameBlocks[i]) {
+					t.Errorf("block #%d differs from the same seed:\n%s\n%s", i+1, hex.EncodeToHex(blocks[i]),
+						hex.EncodeToHex(sameBlocks[i]))
+				}
+				if !bytes.Equal(traces[i], sameTraces[i]) {
+					t.Errorf("trace #%d differs from the same seed:\n%s\n%s", i+1, traces[i], sameTraces[i])
+				}
+				if bytes.Equal(blocks[i], otherBlocks[i]) {
+					t.Errorf("block #%d is the same from another seed", i+1)
+				}
+			}
+		})
+	}
+}
+
+// TestGeneratorChain checks that the generated blocks form a chain: each block is the child of the previous
+// one, its uncles are siblings of its parent, and its trace starts at the state root of its parent and holds
+// the transactions of the block, in order.
+func TestGeneratorChain(t *testing.T) {
+	for _, execute := range []bool{false, true} {
+		t.Run(executionName(execute), func(t *testing.T) {
+			generator := newTestGenerator(t, 42, execute)
+			parent := generator.genesis
+			for number := uint64(1); number <= generatedBlocks; number++ {
+				block, err := generator.Block(number)
+				if err != nil {
+					t.Fatal(err)
+				}
+				trace, err := generator.Trace(number)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if block.Number() != number || block.ParentHash() != parent.Hash {
+					t.Errorf("block #%d with parent %s instead of block #%d with parent %s",
+						block.Number(), block.ParentHash(), number, parent.Hash)
+				}
+				if trace.ParentStateRoot != parent.StateRoot {
+					t.Errorf("block #%d: trace at %s instead of the parent state root %s",
+						number, trace.ParentStateRoot, parent.StateRoot)
+				}
+				if len(block.Uncles) == 0 {
+					t.Errorf("block #%d has no uncle", number)
+				}
+				for i, uncle := range block.Uncles {
+					if uncle.ParentHash != parent.ParentHash || uncle.Number != parent.Number {
+						t.Errorf("block #%d: uncle %d of block #%d with parent %s isn't a sibling of its parent",
+							number, i, uncle.Number, uncle.ParentHash)
+					}
+				}
+
+				if len(trace.TxnTraces) != len(block.Transactions) {
+					t.Fatalf("block #%d: %d transaction traces for %d transactions",
+						number, len(trace.TxnTraces), len(block.Transactions))
+				}
+				for i, txnTrace := range trace.TxnTraces {
+					if !bytes.Equal(txnTrace.Transaction, block.Transactions[i].MarshalRLP()) {
+						t.Errorf("block #%d: transaction trace %d isn't the encoding of transaction %d", number, i, i)
+					}
+				}
+
+				for _, err := range ValidateBlock(block) {
+					t.Errorf("block #%d: %v", number, err)
+				}
+				for _, err := range CheckTrace(block, trace, parent) {
+					t.Errorf("block #%d: %v", number, err)
+				}
+				if execute {
+					missingPaths, err := VerifyWitness(trace)
+					if err != nil || len(missingPaths) > 0 {
+						t.Errorf("block #%d: witness missing %v: %v", number, missingPaths, err)
+					}
+				}
+				parent = block.Header
+			}
+		})
+	}
+}
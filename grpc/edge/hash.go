@@ -0,0 +1,22 @@
+package edge
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+// Transaction hashing scheme of the edge networks the datasets were captured from. The transaction type
+// is part of the encoding used to compute the transaction hashes and the transactions root.
+var txHashFork = &types.TransactionHashForkV2{}
+
+// ComputeTxHash computes the hash of a transaction and sets its `Hash` field.
+func ComputeTxHash(tx *types.Transaction) {
+	txHashFork.ComputeHash(tx)
+}
+
+// CalculateTxRoot calculates the transactions root of a block.
+func CalculateTxRoot(transactions []*types.Transaction) types.Hash {
+	return buildroot.CalculateRoot(len(transactions), func(i int) []byte {
+		return txHashFork.SerializeForRootCalculation(transactions[i], nil)
+	})
+}
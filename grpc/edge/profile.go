@@ -0,0 +1,136 @@
+package edge
+
+import (
+	"sort"
+)
+
+// Profile describes the shape of the random blocks and traces. Ranges are inclusive, and values are drawn
+// uniformly within them.
+type Profile struct {
+	// Share of the blocks without any transaction, and range of the number of transactions of the others.
+	EmptyBlockRatio float64
+	MinTxs, MaxTxs  int
+
+	// Range of the calldata size of the transactions, in bytes.
+	MinCalldataSize, MaxCalldataSize int
+
+	// Share of the transactions deploying a contract, and range of the size of the deployed code, in bytes.
+	DeploymentRatio          float64
+	MinCodeSize, MaxCodeSize int
+
+	// Range of the number of accounts touched by a transaction, and of the storage writes of each of them.
+	MinDeltas, MaxDeltas               int
+	MinStorageWrites, MaxStorageWrites int
+
+	// Range of the number of entries of the account and storage tries of a trace.
+	MinAccountTrieNodes, MaxAccountTrieNodes int
+	MinStorageTrieNodes, MaxStorageTrieNodes int
+}
+
+// DefaultProfile is the name of the profile used when none is given.
+const DefaultProfile = "default"
+
+// Profiles holds the random workload profiles by name. Apart from the default one, they are modeled on the
+// datasets provided under `data/archives`.
+var Profiles = map[string]Profile{
+	// Blocks of 10 transactions with a tiny calldata, each one writing 10 storage slots of a single account.
+	DefaultProfile: {
+		MinTxs:              10,
+		MaxTxs:              10,
+		MinCalldataSize:     3,
+		MaxCalldataSize:     3,
+		MinDeltas:           1,
+		MaxDeltas:           1,
+		MinStorageWrites:    10,
+		MaxStorageWrites:    10,
+		MinAccountTrieNodes: 10,
+		MaxAccountTrieNodes: 10,
+		MinStorageTrieNodes: 10,
+		MaxStorageTrieNodes: 10,
+	},
+	// ERC721 mints: most blocks are empty, the others hold bursts of small calls writing a couple of slots.
+	"erc721-mints": {
+		EmptyBlockRatio:     0.6,
+		MinTxs:              1,
+		MaxTxs:              150,
+		MinCalldataSize:     4,
+		MaxCalldataSize:     68,
+		DeploymentRatio:     0.003,
+		MinCodeSize:         2048,
+		MaxCodeSize:         16384,
+		MinDeltas:           4,
+		MaxDeltas:           4,
+		MinStorageWrites:    0,
+		MaxStorageWrites:    2,
+		MinAccountTrieNodes: 1,
+		MaxAccountTrieNodes: 36,
+		MinStorageTrieNodes: 1,
+		MaxStorageTrieNodes: 16,
+	},
+	// Uniswap swaps and liquidity calls: larger calldata, more accounts touched and more storage writes.
+	"uniswap": {
+		EmptyBlockRatio:     0.3,
+		MinTxs:              1,
+		MaxTxs:              150,
+		MinCalldataSize:     68,
+		MaxCalldataSize:     516,
+		DeploymentRatio:     0.005,
+		MinCodeSize:         4096,
+		MaxCodeSize:         24576,
+		MinDeltas:           4,
+		MaxDeltas:           8,
+		MinStorageWrites:    0,
+		MaxStorageWrites:    6,
+		MinAccountTrieNodes: 1,
+		MaxAccountTrieNodes: 35,
+		MinStorageTrieNodes: 1,
+		MaxStorageTrieNodes: 64,
+	},
+	// A single call per block, storing a value and hashing it.
+	"sstore-sha3": {
+		MinTxs:              1,
+		MaxTxs:              1,
+		MinCalldataSize:     36,
+		MaxCalldataSize:     36,
+		MinDeltas:           4,
+		MaxDeltas:           4,
+		MinStorageWrites:    0,
+		MaxStorageWrites:    1,
+		MinAccountTrieNodes: 10,
+		MaxAccountTrieNodes: 10,
+		MinStorageTrieNodes: 1,
+		MaxStorageTrieNodes: 1,
+	},
+	// Blocks without any transaction.
+	"empty": {
+		EmptyBlockRatio: 1,
+	},
+}
+
+// ProfileNames returns the names of the profiles, sorted in alphabetical order.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Return whether the next random block is empty.
+func (p Profile) emptyBlock() bool {
+	return generateRandomFloat() < p.EmptyBlockRatio
+}
+
+// Return whether the next random transaction deploys a contract.
+func (p Profile) deployment() bool {
+	return generateRandomFloat() < p.DeploymentRatio
+}
+
+// Generate a random integer in [lo, hi].
+func generateRandomIntIn(lo, hi int) int {
+	if hi <= lo {
+		return lo
+	}
+	return lo + int(generateRandomInt(int64(hi-lo+1)))
+}
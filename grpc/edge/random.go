@@ -1,31 +1,70 @@
 package edge
 
 import (
-	"crypto/rand"
 	"math/big"
+	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 )
 
-// GenerateRandomEdgeTrace generates a random `Trace` with random data.
-func GenerateRandomEdgeTrace(accountTriesAmount, storageTriesAmount, storageEntriesAmount, txnTracesAmount int) *types.Trace {
+const (
+	// Gas limit of the random blocks, same as the blocks of the provided datasets.
+	blockGasLimit = 30_000_000
+	// Gap between the timestamps of consecutive random blocks, in seconds.
+	blockTimestampGap = 2
+	// Range of the gas limit of the random transactions, so that 150 transactions fit in a block. The gas limit
+	// of a block is raised when its transactions use more gas.
+	minTxGas = 21_000
+	maxTxGas = blockGasLimit / 150
+)
+
+var (
+	// Source of every random value, seeded with `SetSeed` to get reproducible blocks and traces.
+	random     = rand.New(rand.NewSource(time.Now().UnixNano()))
+	randomLock sync.Mutex
+)
+
+// SetSeed seeds the source of the random values. Generating blocks in the same order from the same seed
+// always yields the same blocks and traces.
+func SetSeed(seed int64) {
+	randomLock.Lock()
+	defer randomLock.Unlock()
+	random = rand.New(rand.NewSource(seed))
+}
+
+// GenerateRandomEdgeTrace generates a random `Trace` for the given block and receipts, child of the given
+// parent state root, shaped by the given profile and target. Transaction traces match the transactions and
+// receipts of the block, the tries and the state changes are random.
+func GenerateRandomEdgeTrace(block *types.Block, receipts []*types.Receipt, parentStateRoot types.Hash,
+	profile Profile, target Target) *types.Trace {
 	trace := &types.Trace{
 		AccountTrie:     make(map[string]string),
 		StorageTrie:     make(map[string]string),
-		ParentStateRoot: *generateRandomHash(),
+		ParentStateRoot: parentStateRoot,
 		TxnTraces:       []*types.TxnTrace{},
 	}
 
 	// Add some random accountTrie entries.
-	for i := 0; i < accountTriesAmount; i++ {
+	accountTrieNodes := generateRandomIntIn(profile.MinAccountTrieNodes, profile.MaxAccountTrieNodes)
+	if target.AccountTrieNodes > 0 {
+		accountTrieNodes = target.AccountTrieNodes
+	}
+	for i := 0; i < accountTrieNodes; i++ {
 		key := generateRandomHash()
 		value := generateRandomHash()
 		trace.AccountTrie[key.String()] = value.String()
 	}
 
 	// Add some random storageTrie entries.
-	for i := 0; i < storageTriesAmount; i++ {
+	storageTrieNodes := generateRandomIntIn(profile.MinStorageTrieNodes, profile.MaxStorageTrieNodes)
+	if target.StorageTrieNodes > 0 {
+		storageTrieNodes = target.StorageTrieNodes
+	}
+	for i := 0; i < storageTrieNodes; i++ {
 		key := generateRandomHash()
 		value := generateRandomHash()
 		trace.StorageTrie[key.String()] = value.String()
@@ -33,30 +72,28 @@ func GenerateRandomEdgeTrace(accountTriesAmount, storageTriesAmount, storageEntr
 
 	// Add some random TxnTraces.
 	generateRandomBool := func() *bool {
-		b, _ := rand.Int(rand.Reader, big.NewInt(2))
-		res := b.Int64() == 1
+		res := generateRandomInt(2) == 1
 		return &res
 	}
 
 	generateRandomNonce := func() *uint64 {
-		n, _ := rand.Int(rand.Reader, big.NewInt(100))
-		nonce := uint64(n.Uint64())
+		nonce := uint64(generateRandomInt(100))
 		return &nonce
 	}
 
-	generateRandomJournalEntry := func() *types.JournalEntry {
+	generateRandomJournalEntry := func(address types.Address) *types.JournalEntry {
 		entry := &types.JournalEntry{
-			Addr:    *generateRandomAddress(),
+			Addr:    address,
 			Balance: generateRandomBigInt(),
 			Nonce:   generateRandomNonce(),
 			Storage: make(map[types.Hash]types.Hash),
-			Code:    generateRandomByteSlice(64),
 			Suicide: generateRandomBool(),
 			Touched: generateRandomBool(),
 		}
 
 		// Add some random storage entries.
-		for i := 0; i < storageEntriesAmount; i++ {
+		storageWrites := generateRandomIntIn(profile.MinStorageWrites, profile.MaxStorageWrites)
+		for i := 0; i < storageWrites; i++ {
 			key := *generateRandomHash()
 			value := *generateRandomHash()
 			entry.Storage[key] = value
@@ -65,127 +102,196 @@ func GenerateRandomEdgeTrace(accountTriesAmount, storageTriesAmount, storageEntr
 		return entry
 	}
 
-	generateRandomTxnTrace := func(nonce uint64) *types.TxnTrace {
-		txn := generateRandomTx(nonce)
-		receipt := generateRandomReceipt()
+	// The receipt root of a transaction trace is the root of the receipts up to that transaction, like edge
+	// computes it. The transactions root is left empty, edge doesn't fill it either.
+	generateRandomTxnTrace := func(i int) *types.TxnTrace {
+		txn, receipt := block.Transactions[i], receipts[i]
+		delta := make(map[types.Address]*types.JournalEntry)
+
+		// A deployment stores the code of the created contract.
+		if txn.To == nil {
+			entry := generateRandomJournalEntry(*receipt.ContractAddress)
+			entry.Code = txn.Input
+			delta[*receipt.ContractAddress] = entry
+		}
+		deltas := generateRandomIntIn(profile.MinDeltas, profile.MaxDeltas)
+		for len(delta) < deltas {
+			address := *generateRandomAddress()
+			delta[address] = generateRandomJournalEntry(address)
+		}
+
 		return &types.TxnTrace{
 			Transaction: txn.MarshalRLP(),
-			Delta: map[types.Address]*types.JournalEntry{
-				*generateRandomAddress(): generateRandomJournalEntry(),
-			},
-			ReceiptRoot: *generateRandomHash(),
+			Delta:       delta,
+			ReceiptRoot: buildroot.CalculateReceiptsRoot(receipts[:i+1]),
 			Receipt:     receipt.MarshalRLP(),
-			TxnRoot:     *generateRandomHash(),
-			Hash:        *generateRandomHash(),
-			GasUsed:     generateRandomBigInt().Uint64(),
-			Bloom:       types.Bloom{},
+			Hash:        txn.Hash,
+			GasUsed:     receipt.GasUsed,
+			Bloom:       receipt.LogsBloom,
 		}
 	}
 
-	for i := 0; i < txnTracesAmount; i++ {
-		trace.TxnTraces = append(trace.TxnTraces, generateRandomTxnTrace(uint64(i)))
+	for i := range block.Transactions {
+		trace.TxnTraces = append(trace.TxnTraces, generateRandomTxnTrace(i))
 	}
 
+	if target.TraceBytes > 0 {
+		padTrace(trace, target.TraceBytes)
+	}
 	return trace
 }
 
-// GenerateRandomEdgeBlock generates a random `Block` with random data.
-func GenerateRandomEdgeBlock(number, txnTracesAmount uint64) *types.Block {
-	// Generate a random EdgeBlock.
+// GenerateRandomEdgeBlock generates a random `Block` with random data, child of the given parent header and
+// shaped by the given profile and target. Transactions are signed by the accounts of the wallet. The header
+// hash, the transaction hashes and the roots of the header are computed from the content of the block. The
+// receipts of the transactions are returned along with the block.
+func GenerateRandomEdgeBlock(parent *types.Header, profile Profile, target Target,
+	wallet *Wallet) (*types.Block, []*types.Receipt, error) {
+	number := parent.Number + 1
+	timestamp := parent.Timestamp + blockTimestampGap
+
+	// Generate a list of random transactions along with their receipts.
+	var transactions []*types.Transaction
+	var receipts []*types.Receipt
+	var cumulativeGasUsed uint64
+	txsAmount := 0
+	if !profile.emptyBlock() {
+		txsAmount = generateRandomIntIn(profile.MinTxs, profile.MaxTxs)
+	}
+	if target.Txs > 0 {
+		txsAmount = target.Txs
+	}
+	if target.Gas > 0 && txsAmount == 0 {
+		txsAmount = 1
+	}
+	for i := 0; i < txsAmount; i++ {
+		// Split the targeted gas between the transactions, the last one using the remainder.
+		var gas uint64
+		if target.Gas > 0 {
+			gas = target.Gas / uint64(txsAmount)
+			if i == txsAmount-1 {
+				gas = target.Gas - gas*uint64(txsAmount-1)
+			}
+		}
+
+		tx, err := wallet.Sign(generateRandomTx(profile, gas))
+		if err != nil {
+			return nil, nil, err
+		}
+		transactions = append(transactions, tx)
+
+		gasUsed := gas
+		if target.Gas == 0 {
+			gasUsed = generateRandomBigInt().Uint64() % (tx.Gas + 1)
+		}
+		receipt := generateRandomReceipt(tx, cumulativeGasUsed, gasUsed)
+		cumulativeGasUsed = receipt.CumulativeGasUsed
+		receipts = append(receipts, receipt)
+	}
+
+	uncles := generateRandomUncles(parent)
+
 	header := &types.Header{
-		ParentHash:   *generateRandomHash(),
-		Sha3Uncles:   *generateRandomHash(),
+		ParentHash:   parent.Hash,
+		Sha3Uncles:   buildroot.CalculateUncleRoot(uncles),
 		Miner:        []byte{1, 2, 3},
 		StateRoot:    *generateRandomHash(),
-		TxRoot:       *generateRandomHash(),
-		ReceiptsRoot: *generateRandomHash(),
+		TxRoot:       CalculateTxRoot(transactions),
+		ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
 		LogsBloom:    types.Bloom{},
 		Difficulty:   generateRandomBigInt().Uint64(),
 		Number:       number,
-		GasLimit:     generateRandomBigInt().Uint64(),
-		GasUsed:      generateRandomBigInt().Uint64(),
-		Timestamp:    uint64(time.Now().Unix()),
+		GasLimit:     max(blockGasLimit, cumulativeGasUsed),
+		GasUsed:      cumulativeGasUsed,
+		Timestamp:    timestamp,
 		ExtraData:    []byte{4, 5, 6},
 		MixHash:      *generateRandomHash(),
 		Nonce:        types.Nonce{7, 8, 9, 10, 11, 12, 13, 14},
-		Hash:         *generateRandomHash(),
 		BaseFee:      generateRandomBigInt().Uint64(),
 	}
 
-	// Generate a list of random transactions.
-	var transactions []*types.Transaction
-	var i uint64
-	for i = 0; i < txnTracesAmount; i++ {
-		transactions = append(transactions, generateRandomTx(i))
+	block := &types.Block{
+		Header:       header.ComputeHash(),
+		Transactions: transactions,
+		Uncles:       uncles,
 	}
+	return block, receipts, nil
+}
 
-	// Generate a list of random uncles.
+// Generate a list of random uncles, siblings of the parent header: they share its parent and its number.
+func generateRandomUncles(parent *types.Header) []*types.Header {
 	var uncles []*types.Header
 	for i := 0; i < 2; i++ {
-		uncles = append(uncles, &types.Header{
-			ParentHash:   *generateRandomHash(),
-			Sha3Uncles:   *generateRandomHash(),
+		uncle := &types.Header{
+			ParentHash:   parent.ParentHash,
+			Sha3Uncles:   types.EmptyUncleHash,
 			Miner:        []byte{1, 2, 3},
 			StateRoot:    *generateRandomHash(),
-			TxRoot:       *generateRandomHash(),
-			ReceiptsRoot: *generateRandomHash(),
+			TxRoot:       types.EmptyRootHash,
+			ReceiptsRoot: types.EmptyRootHash,
 			LogsBloom:    types.Bloom{},
 			Difficulty:   generateRandomBigInt().Uint64(),
-			Number:       generateRandomBigInt().Uint64(),
-			GasLimit:     generateRandomBigInt().Uint64(),
-			GasUsed:      generateRandomBigInt().Uint64(),
-			Timestamp:    uint64(time.Now().Unix()),
+			Number:       parent.Number,
+			GasLimit:     blockGasLimit,
+			GasUsed:      0,
+			Timestamp:    parent.Timestamp,
 			ExtraData:    []byte{4, 5, 6},
 			MixHash:      *generateRandomHash(),
 			Nonce:        types.Nonce{7, 8, 9, 10, 11, 12, 13, 14},
-			Hash:         *generateRandomHash(),
 			BaseFee:      5,
-		})
-	}
-
-	return &types.Block{
-		Header:       header,
-		Transactions: transactions,
-		Uncles:       uncles,
+		}
+		uncles = append(uncles, uncle.ComputeHash())
 	}
+	return uncles
 }
 
-func generateRandomTx(nonce uint64) *types.Transaction {
-	randomAddress := generateRandomAddress()
-	return &types.Transaction{
-		Nonce:     nonce,
-		GasPrice:  generateRandomBigInt(),
-		GasTipCap: generateRandomBigInt(),
-		GasFeeCap: generateRandomBigInt(),
-		Gas:       generateRandomBigInt().Uint64(),
-		To:        randomAddress,
-		Value:     generateRandomBigInt(),
-		Input:     []byte{1, 2, 3},
-		V:         generateRandomBigInt(),
-		R:         generateRandomBigInt(),
-		S:         generateRandomBigInt(),
-		Hash:      *generateRandomHash(),
-		From:      *randomAddress,
-		Type:      types.TxType(0),
-		ChainID:   generateRandomBigInt(),
+// Generate an unsigned transaction, either a legacy or a dynamic fee one, calling or deploying a contract.
+// The gas limit of the transaction is random when the given one is zero. The nonce, the chain ID, the
+// signature and the sender are set when signing it. Access list transactions aren't generated since edge
+// doesn't support them.
+func generateRandomTx(profile Profile, gas uint64) *types.Transaction {
+	if gas == 0 {
+		gas = uint64(generateRandomIntIn(minTxGas, maxTxGas))
+	}
+	tx := &types.Transaction{
+		Gas:   gas,
+		Value: generateRandomBigInt(),
 	}
+	if profile.deployment() {
+		tx.Input = generateRandomByteSlice(generateRandomIntIn(profile.MinCodeSize, profile.MaxCodeSize))
+	} else {
+		tx.To = generateRandomAddress()
+		tx.Input = generateRandomByteSlice(generateRandomIntIn(profile.MinCalldataSize, profile.MaxCalldataSize))
+	}
+	if generateRandomInt(2) == 1 {
+		tx.Type = types.DynamicFeeTx
+		tx.GasTipCap = generateRandomBigInt()
+		tx.GasFeeCap = new(big.Int).Add(tx.GasTipCap, generateRandomBigInt())
+	} else {
+		tx.Type = types.LegacyTx
+		tx.GasPrice = generateRandomBigInt()
+	}
+	return tx
 }
 
-func generateRandomReceipt() *types.Receipt {
+// Generate the receipt of a successful transaction, given the gas it used and the gas used by the previous
+// transactions of the block.
+func generateRandomReceipt(tx *types.Transaction, cumulativeGasUsed, gasUsed uint64) *types.Receipt {
 	status := types.ReceiptStatus(types.ReceiptSuccess)
-	gasUsed := generateRandomBigInt().Uint64()
-	return &types.Receipt{
-		Root:              *generateRandomHash(),
-		CumulativeGasUsed: generateRandomBigInt().Uint64() * gasUsed,
+	receipt := &types.Receipt{
+		CumulativeGasUsed: cumulativeGasUsed + gasUsed,
 		LogsBloom:         types.Bloom{},
 		Logs:              []*types.Log{},
 		Status:            &status,
 
 		GasUsed:         gasUsed,
-		ContractAddress: generateRandomAddress(),
-		TxHash:          *generateRandomHash(),
-		TransactionType: types.LegacyTx,
+		TxHash:          tx.Hash,
+		TransactionType: tx.Type,
+	}
+	if tx.To == nil {
+		receipt.SetContractAddress(crypto.CreateAddress(tx.From, tx.Nonce))
 	}
+	return receipt
 }
 
 func generateRandomHash() *types.Hash {
@@ -203,15 +309,27 @@ func generateRandomAddress() *types.Address {
 }
 
 func generateRandomBigInt() *big.Int {
-	n, _ := rand.Int(rand.Reader, big.NewInt(1000000))
-	return n
+	return big.NewInt(generateRandomInt(1000000))
 }
 
 func generateRandomByteSlice(length int) []byte {
+	randomLock.Lock()
+	defer randomLock.Unlock()
 	b := make([]byte, length)
-	_, err := rand.Read(b)
-	if err != nil {
-		return nil
-	}
+	random.Read(b)
 	return b
 }
+
+// Generate a random float in [0, 1).
+func generateRandomFloat() float64 {
+	randomLock.Lock()
+	defer randomLock.Unlock()
+	return random.Float64()
+}
+
+// Generate a random integer in [0, n).
+func generateRandomInt(n int64) int64 {
+	randomLock.Lock()
+	defer randomLock.Unlock()
+	return random.Int63n(n)
+}
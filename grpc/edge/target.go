@@ -0,0 +1,78 @@
+package edge
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Approximate size of a storage entry of a journal entry once the trace is encoded using JSON.
+const storageEntrySize = len(`"0x":"0x",`) + 4*types.HashLength
+
+// Target sets the size of the random blocks and traces, overriding the profile. Zero values aren't
+// targeted, and are left to the profile.
+type Target struct {
+	// Number of transactions of a block.
+	Txs int
+	// Gas used by a block, split between its transactions.
+	Gas uint64
+	// Number of entries of the account and storage tries of a trace.
+	AccountTrieNodes int
+	StorageTrieNodes int
+	// Approximate size of a trace encoded using JSON, in bytes, reached by adding storage writes to the state
+	// changes of the transactions, or entries to the storage trie when there is no state change.
+	TraceBytes int
+}
+
+// Scale returns the target multiplied by the given factor.
+func (t Target) Scale(factor int) Target {
+	return Target{
+		Txs:              t.Txs * factor,
+		Gas:              t.Gas * uint64(factor),
+		AccountTrieNodes: t.AccountTrieNodes * factor,
+		StorageTrieNodes: t.StorageTrieNodes * factor,
+		TraceBytes:       t.TraceBytes * factor,
+	}
+}
+
+// Add random storage writes to the trace until its encoding reaches the given size, or storage trie entries
+// when the trace holds no journal entry. Journal entries are padded in turn, in the order of the transactions
+// and of the addresses, so that the trace stays the same for a given seed.
+func padTrace(trace *types.Trace, size int) {
+	var entries []*types.JournalEntry
+	for _, txnTrace := range trace.TxnTraces {
+		addresses := make([]types.Address, 0, len(txnTrace.Delta))
+		for address := range txnTrace.Delta {
+			addresses = append(addresses, address)
+		}
+		sort.Slice(addresses, func(i, j int) bool {
+			return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+		})
+		for _, address := range addresses {
+			entries = append(entries, txnTrace.Delta[address])
+		}
+	}
+
+	for i := 0; ; {
+		encoded, err := json.Marshal(trace)
+		if err != nil || len(encoded) >= size {
+			return
+		}
+
+		for n := (size-len(encoded))/storageEntrySize + 1; n > 0; n-- {
+			key, value := generateRandomHash(), generateRandomHash()
+			if len(entries) == 0 {
+				trace.StorageTrie[key.String()] = value.String()
+				continue
+			}
+			entry := entries[i%len(entries)]
+			if entry.Storage == nil {
+				entry.Storage = make(map[types.Hash]types.Hash)
+			}
+			entry.Storage[*key] = *value
+			i++
+		}
+	}
+}
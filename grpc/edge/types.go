@@ -2,6 +2,7 @@ package edge
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
@@ -9,85 +10,114 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// HeaderRPC represents a block header returned by the edge RPC.
+type HeaderRPC struct {
+	ParentHash   types.Hash  `json:"parentHash"`
+	Sha3Uncles   types.Hash  `json:"sha3Uncles"`
+	Miner        argBytes    `json:"miner"`
+	StateRoot    types.Hash  `json:"stateRoot"`
+	TxRoot       types.Hash  `json:"transactionsRoot"`
+	ReceiptsRoot types.Hash  `json:"receiptsRoot"`
+	LogsBloom    types.Bloom `json:"logsBloom"`
+	Difficulty   argUint64   `json:"difficulty"`
+	Number       argUint64   `json:"number"`
+	GasLimit     argUint64   `json:"gasLimit"`
+	GasUsed      argUint64   `json:"gasUsed"`
+	Timestamp    argUint64   `json:"timestamp"`
+	ExtraData    argBytes    `json:"extraData"`
+	MixHash      types.Hash  `json:"mixHash"`
+	Nonce        types.Nonce `json:"nonce"`
+	Hash         types.Hash  `json:"hash"`
+	BaseFee      *argUint64  `json:"baseFeePerGas,omitempty"`
+	// Base fee recorded by older versions of edge, used when `baseFeePerGas` is missing or null.
+	LegacyBaseFee *argUint64 `json:"baseFee,omitempty"`
+}
+
+func (h *HeaderRPC) toHeaderGrpc() *types.Header {
+	baseFee := h.BaseFee
+	if baseFee == nil {
+		baseFee = h.LegacyBaseFee
+	}
+	header := &types.Header{
+		ParentHash:   h.ParentHash,
+		Sha3Uncles:   h.Sha3Uncles,
+		Miner:        h.Miner,
+		StateRoot:    h.StateRoot,
+		TxRoot:       h.TxRoot,
+		ReceiptsRoot: h.ReceiptsRoot,
+		LogsBloom:    h.LogsBloom,
+		Difficulty:   uint64(h.Difficulty),
+		Number:       uint64(h.Number),
+		GasLimit:     uint64(h.GasLimit),
+		GasUsed:      uint64(h.GasUsed),
+		Timestamp:    uint64(h.Timestamp),
+		ExtraData:    h.ExtraData,
+		MixHash:      h.MixHash,
+		Nonce:        h.Nonce,
+		Hash:         h.Hash,
+	}
+	if baseFee != nil {
+		header.BaseFee = uint64(*baseFee)
+	}
+	return header
+}
+
 // BlockRPC represents a block returned by the edge RPC.
+// The edge RPC only lists the hashes of the uncles, their full headers can be given in `uncleHeaders`, in the
+// order of the hashes, so that the block can be encoded using RLP.
 type BlockRPC struct {
-	ParentHash      types.Hash       `json:"parentHash"`
-	Sha3Uncles      types.Hash       `json:"sha3Uncles"`
-	Miner           argBytes         `json:"miner"`
-	StateRoot       types.Hash       `json:"stateRoot"`
-	TxRoot          types.Hash       `json:"transactionsRoot"`
-	ReceiptsRoot    types.Hash       `json:"receiptsRoot"`
-	LogsBloom       types.Bloom      `json:"logsBloom"`
-	Difficulty      argUint64        `json:"difficulty"`
+	HeaderRPC
 	TotalDifficulty argUint64        `json:"totalDifficulty"`
 	Size            argUint64        `json:"size"`
-	Number          argUint64        `json:"number"`
-	GasLimit        argUint64        `json:"gasLimit"`
-	GasUsed         argUint64        `json:"gasUsed"`
-	Timestamp       argUint64        `json:"timestamp"`
-	ExtraData       argBytes         `json:"extraData"`
-	MixHash         types.Hash       `json:"mixHash"`
-	Nonce           types.Nonce      `json:"nonce"`
-	Hash            types.Hash       `json:"hash"`
 	Transactions    []TransactionRPC `json:"transactions"`
 	Uncles          []types.Hash     `json:"uncles"`
-	BaseFee         argUint64        `json:"baseFeePerGas,omitempty"`
+	UncleHeaders    []HeaderRPC      `json:"uncleHeaders,omitempty"`
 }
 
-func (b *BlockRPC) ToBlockGrpc() *types.Block {
-	header := types.Header{
-		ParentHash:   b.ParentHash,
-		Sha3Uncles:   b.Sha3Uncles,
-		Miner:        b.Miner,
-		StateRoot:    b.StateRoot,
-		TxRoot:       b.TxRoot,
-		ReceiptsRoot: b.ReceiptsRoot,
-		LogsBloom:    b.LogsBloom,
-		Difficulty:   uint64(b.Difficulty),
-		Number:       uint64(b.Number),
-		GasLimit:     uint64(b.GasLimit),
-		GasUsed:      uint64(b.GasUsed),
-		Timestamp:    uint64(b.Timestamp),
-		ExtraData:    b.ExtraData,
-		MixHash:      b.MixHash,
-		Nonce:        b.Nonce,
-		Hash:         b.Hash,
-		BaseFee:      uint64(b.BaseFee),
-	}
+// ToBlockGrpc converts the block to the edge format. An error is returned when one of its transactions can't be
+// represented in the edge format without changing its hash.
+func (b *BlockRPC) ToBlockGrpc() (*types.Block, error) {
+	header := b.toHeaderGrpc()
 
+	// Iterate by index: the transactions point to the big integers of the RPC transactions, a copy of each
+	// transaction would make them all share the values of the last one.
 	transactions := make([]*types.Transaction, len(b.Transactions))
-	for i, txGrpc := range b.Transactions {
-		txRPC := txGrpc.toTransactionGrpc()
-		if txRPC != nil {
-			transactions[i] = txRPC
+	for i := range b.Transactions {
+		tx, err := b.Transactions[i].toTransactionGrpc()
+		if err != nil {
+			return nil, fmt.Errorf("transaction #%d (%s): %w", i, b.Transactions[i].Hash, err)
 		}
+		transactions[i] = tx
 	}
 
-	// Note: we don't parse uncles for the moment.
 	var uncles []*types.Header
+	for i := range b.UncleHeaders {
+		uncles = append(uncles, b.UncleHeaders[i].toHeaderGrpc())
+	}
 
 	return &types.Block{
-		Header:       &header,
+		Header:       header,
 		Transactions: transactions,
 		Uncles:       uncles,
-	}
+	}, nil
 }
 
 // TransactionRPC represents a transaction returned by the edge RPC.
 type TransactionRPC struct {
-	Nonce     argUint64      `json:"nonce"`
-	GasPrice  *argBig        `json:"gasPrice,omitempty"`
-	GasTipCap *argBig        `json:"maxPriorityFeePerGas,omitempty"`
-	GasFeeCap *argBig        `json:"maxFeePerGas,omitempty"`
-	Gas       argUint64      `json:"gas"`
-	To        *types.Address `json:"to"`
-	Value     argBig         `json:"value"`
-	Input     argBytes       `json:"input"`
-	V         argBig         `json:"v"`
-	R         argBig         `json:"r"`
-	S         argBig         `json:"s"`
-	Hash      types.Hash     `json:"hash"`
-	From      types.Address  `json:"from"`
+	Nonce      argUint64      `json:"nonce"`
+	GasPrice   *argBig        `json:"gasPrice,omitempty"`
+	GasTipCap  *argBig        `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap  *argBig        `json:"maxFeePerGas,omitempty"`
+	Gas        argUint64      `json:"gas"`
+	To         *types.Address `json:"to"`
+	Value      argBig         `json:"value"`
+	Input      argBytes       `json:"input"`
+	AccessList []AccessTuple  `json:"accessList,omitempty"`
+	V          argBig         `json:"v"`
+	R          argBig         `json:"r"`
+	S          argBig         `json:"s"`
+	Hash       types.Hash     `json:"hash"`
+	From       types.Address  `json:"from"`
 
 	// Additional fields.
 	BlockHash   *types.Hash `json:"blockHash"`
@@ -97,7 +127,35 @@ type TransactionRPC struct {
 	Type        argUint64   `json:"type"`
 }
 
-func (tx *TransactionRPC) toTransactionGrpc() *types.Transaction {
+// AccessTuple represents an entry of the access list of a transaction, as defined by EIP-2930.
+type AccessTuple struct {
+	Address     types.Address `json:"address"`
+	StorageKeys []types.Hash  `json:"storageKeys"`
+}
+
+// Convert the transaction to the edge format, which supports legacy, state and dynamic fee transactions.
+// The pinned edge version always encodes dynamic fee transactions with an empty access list, and doesn't define
+// access list transactions at all: their encoding wouldn't match their hash, so such transactions are rejected
+// until edge is upgraded to a version supporting them.
+func (tx *TransactionRPC) toTransactionGrpc() (*types.Transaction, error) {
+	txType := types.TxType(tx.Type)
+	switch txType {
+	case types.LegacyTx, types.StateTx:
+		if tx.GasPrice == nil {
+			return nil, fmt.Errorf("%s without gasPrice", txType)
+		}
+	case types.DynamicFeeTx:
+		if tx.GasTipCap == nil || tx.GasFeeCap == nil {
+			return nil, fmt.Errorf("%s without maxPriorityFeePerGas or maxFeePerGas", txType)
+		}
+	default:
+		return nil, fmt.Errorf("transaction type %#x isn't supported by edge", uint64(tx.Type))
+	}
+	if len(tx.AccessList) > 0 {
+		return nil, fmt.Errorf("access lists aren't supported by edge, the transaction lists %d addresses",
+			len(tx.AccessList))
+	}
+
 	return &types.Transaction{
 		Nonce:     uint64(tx.Nonce),
 		GasPrice:  (*big.Int)(tx.GasPrice),
@@ -112,9 +170,9 @@ func (tx *TransactionRPC) toTransactionGrpc() *types.Transaction {
 		S:         (*big.Int)(&tx.S),
 		Hash:      types.Hash(tx.Hash),
 		From:      types.Address(tx.From),
-		Type:      types.TxType(tx.Type),
+		Type:      txType,
 		ChainID:   (*big.Int)(tx.ChainID),
-	}
+	}, nil
 }
 
 type argUint64 uint64
@@ -149,7 +207,7 @@ func (b argBytes) MarshalText() ([]byte, error) {
 func (b *argBytes) UnmarshalText(input []byte) error {
 	hh, err := decodeToHex(input)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	aux := make([]byte, len(hh))
@@ -0,0 +1,204 @@
+package edge
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Transactions in the edge RPC format. The legacy and dynamic fee transactions come from the
+// `mock-mix-and-uniswap` dataset, the state transaction and the contract creation are made up.
+const (
+	legacyTxRPC = `{
+		"nonce": "0x11",
+		"gasPrice": "0x96923fd4",
+		"gas": "0xaf18",
+		"to": "0xF7012159bF761B312153e8c8D176932Fe9aAA7eA",
+		"value": "0x0",
+		"input": "0x095ea7b3000000000000000000000000d15f63c56aeceb772022eb0f3fec874b5157a36a0000000000000000000000000000000000000000204fce5e3e25026110000000",
+		"v": "0xfc6",
+		"r": "0xf6acedaaceb74c73c4061c8549d5a7054cd254790f31ee529547dfac0786a0f6",
+		"s": "0x36c2ebfd8da1a15090d50a63d6cf0c9b3e87d444899fe15e19655443e72aba33",
+		"hash": "0x59cf2f3f0797d246c54f3d45e10790be7a1336e7f1ae887a928a5c61a8e8410d",
+		"from": "0x85dA99c8a7C2C95964c8EfD687E95E632Fc533D6",
+		"type": "0x0"
+	}`
+	dynamicFeeTxRPC = `{
+		"nonce": "0x1553",
+		"gasPrice": "0xb2d05e07",
+		"maxPriorityFeePerGas": "0xb2d05e00",
+		"maxFeePerGas": "0xb2d05e0e",
+		"gas": "0x1540029",
+		"to": "0xC3f3338ed0fd9C2013bd26c6b4DA337858A486a8",
+		"value": "0x0",
+		"input": "0xce1b193a00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000ffef",
+		"v": "0x1",
+		"r": "0xf85ef03db2fe0a44c9c21e86e31763c5b288a1f7bfb89ad67cc697b820ca4172",
+		"s": "0x309ec83f1da9c149ae7801eae1f4caad075947502b28094e76d7c2412895f921",
+		"hash": "0xc49fa4c07426695cbaaee9f4d32d0707b904c222290f20677d29b4abd836a786",
+		"from": "0x85dA99c8a7C2C95964c8EfD687E95E632Fc533D6",
+		"chainId": "0x7d1",
+		"type": "0x2"
+	}`
+	stateTxRPC = `{
+		"nonce": "0x3",
+		"gasPrice": "0x0",
+		"gas": "0x989680",
+		"to": "0x0000000000000000000000000000000000001001",
+		"value": "0x0",
+		"input": "0xdead",
+		"v": "0x0",
+		"r": "0x0",
+		"s": "0x0",
+		"hash": "0xa877d3419ca4365f1e08e403d8de87a20209d86318f113eb651cf7fd24f892ad",
+		"from": "0xffffFFFfFFffffffffffffffFfFFFfffFFFfFFfE",
+		"type": "0x7f"
+	}`
+	contractCreationTxRPC = `{
+		"nonce": "0x1",
+		"gasPrice": "0x3b9aca00",
+		"gas": "0x5208",
+		"to": null,
+		"value": "0x0",
+		"input": "0x60006000f3",
+		"v": "0xfc5",
+		"r": "0x010203",
+		"s": "0x040506",
+		"hash": "0xd619b91060657b8201772e2e23a50a0f5b53f259141c3e8293ac95e39b4cb2d7",
+		"type": "0x0"
+	}`
+)
+
+// TestTransactionRoundTrip checks that every transaction type supported by edge is converted from the edge RPC
+// format so that it hashes as recorded, and that its RLP encoding decodes back to the same transaction.
+func TestTransactionRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		rpc  string
+	}{
+		{"legacy", legacyTxRPC},
+		{"dynamic fee", dynamicFeeTxRPC},
+		{"state", stateTxRPC},
+		{"contract creation", contractCreationTxRPC},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var txRPC TransactionRPC
+			if err := json.Unmarshal([]byte(test.rpc), &txRPC); err != nil {
+				t.Fatal(err)
+			}
+			tx, err := txRPC.toTransactionGrpc()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			computed := tx.Copy()
+			ComputeTxHash(computed)
+			if computed.Hash != txRPC.Hash {
+				t.Errorf("hashes to %s instead of %s", computed.Hash, txRPC.Hash)
+			}
+
+			encoded := tx.MarshalRLP()
+			var decoded types.Transaction
+			if err := decoded.UnmarshalRLP(encoded); err != nil {
+				t.Fatal(err)
+			}
+			if reencoded := decoded.MarshalRLP(); !bytes.Equal(reencoded, encoded) {
+				t.Errorf("encoded as %x once decoded instead of %x", reencoded, encoded)
+			}
+			checkTransaction(t, &decoded, tx)
+		})
+	}
+}
+
+// TestTransactionRejected checks that transactions edge can't encode without changing their hash are rejected.
+func TestTransactionRejected(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		rpc   string
+		patch map[string]interface{}
+		err   string
+	}{
+		{"access list", legacyTxRPC, map[string]interface{}{"type": "0x1"}, "type 0x1 isn't supported"},
+		{"blob", dynamicFeeTxRPC, map[string]interface{}{"type": "0x3"}, "type 0x3 isn't supported"},
+		{
+			"dynamic fee with access list",
+			dynamicFeeTxRPC,
+			map[string]interface{}{"accessList": []map[string]interface{}{{
+				"address":     "0xC3f3338ed0fd9C2013bd26c6b4DA337858A486a8",
+				"storageKeys": []string{"0x0000000000000000000000000000000000000000000000000000000000000001"},
+			}}},
+			"access lists aren't supported",
+		},
+		{"legacy without gas price", legacyTxRPC, map[string]interface{}{"gasPrice": nil}, "without gasPrice"},
+		{"dynamic fee without fee cap", dynamicFeeTxRPC, map[string]interface{}{"maxFeePerGas": nil}, "without maxPriorityFeePerGas or maxFeePerGas"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var fields map[string]interface{}
+			if err := json.Unmarshal([]byte(test.rpc), &fields); err != nil {
+				t.Fatal(err)
+			}
+			for key, value := range test.patch {
+				fields[key] = value
+			}
+			encoded, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var txRPC TransactionRPC
+			if err := json.Unmarshal(encoded, &txRPC); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := txRPC.toTransactionGrpc(); err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+// Check that a transaction decoded from RLP holds the fields of the transaction it was encoded from.
+func checkTransaction(t *testing.T, decoded, tx *types.Transaction) {
+	t.Helper()
+	if decoded.Type != tx.Type || decoded.Nonce != tx.Nonce || decoded.Gas != tx.Gas {
+		t.Errorf("decoded as type %s, nonce %d and gas %d instead of type %s, nonce %d and gas %d",
+			decoded.Type, decoded.Nonce, decoded.Gas, tx.Type, tx.Nonce, tx.Gas)
+	}
+	if (decoded.To == nil) != (tx.To == nil) || (tx.To != nil && *decoded.To != *tx.To) {
+		t.Errorf("decoded with recipient %v instead of %v", decoded.To, tx.To)
+	}
+	if !bytes.Equal(decoded.Input, tx.Input) {
+		t.Errorf("decoded with input %x instead of %x", decoded.Input, tx.Input)
+	}
+	for _, field := range []struct {
+		name              string
+		decoded, expected interface{ String() string }
+	}{
+		{"value", decoded.Value, tx.Value},
+		{"v", decoded.V, tx.V},
+		{"r", decoded.R, tx.R},
+		{"s", decoded.S, tx.S},
+	} {
+		if field.decoded.String() != field.expected.String() {
+			t.Errorf("decoded with %s %s instead of %s", field.name, field.decoded, field.expected)
+		}
+	}
+
+	switch tx.Type {
+	case types.DynamicFeeTx:
+		if decoded.GasTipCap.Cmp(tx.GasTipCap) != 0 || decoded.GasFeeCap.Cmp(tx.GasFeeCap) != 0 ||
+			decoded.ChainID.Cmp(tx.ChainID) != 0 {
+			t.Errorf("decoded with fee caps %s and %s and chain ID %s instead of %s, %s and %s",
+				decoded.GasTipCap, decoded.GasFeeCap, decoded.ChainID, tx.GasTipCap, tx.GasFeeCap, tx.ChainID)
+		}
+	default:
+		if decoded.GasPrice.Cmp(tx.GasPrice) != 0 {
+			t.Errorf("decoded with gas price %s instead of %s", decoded.GasPrice, tx.GasPrice)
+		}
+	}
+	if tx.Type == types.StateTx && decoded.From != tx.From {
+		t.Errorf("decoded with sender %s instead of %s", decoded.From, tx.From)
+	}
+}
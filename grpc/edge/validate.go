@@ -0,0 +1,97 @@
+package edge
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ValidateBlock recomputes the header hash, the hash of each transaction and the transactions root of a
+// block decoded from the edge RPC format, and compares them to the values it was decoded with. Transaction
+// hashes are computed from their RLP encoding, which must also decode back to the same transaction. An error is
+// returned for every field that doesn't match.
+func ValidateBlock(block *types.Block) []error {
+	var errs []error
+	for i, tx := range block.Transactions {
+		computed := tx.Copy()
+		ComputeTxHash(computed)
+		if computed.Hash != tx.Hash {
+			errs = append(errs, fieldError(fmt.Sprintf("transactions[%d].hash", i), tx.Hash, computed.Hash))
+		}
+
+		encoded := tx.MarshalRLP()
+		var decoded types.Transaction
+		if err := decoded.UnmarshalRLP(encoded); err != nil {
+			errs = append(errs, fmt.Errorf("transactions[%d] can't be decoded from its RLP encoding: %w", i, err))
+		} else if !bytes.Equal(decoded.MarshalRLP(), encoded) {
+			errs = append(errs, fmt.Errorf("transactions[%d] doesn't match its RLP encoding once decoded", i))
+		}
+	}
+	if root := CalculateTxRoot(block.Transactions); root != block.Header.TxRoot {
+		errs = append(errs, fieldError("transactionsRoot", block.Header.TxRoot, root))
+	}
+	if hash := block.Header.Copy().ComputeHash().Hash; hash != block.Header.Hash {
+		errs = append(errs, fieldError("hash", block.Header.Hash, hash))
+	}
+	return errs
+}
+
+// CheckTrace checks that a trace belongs to the given block. Each transaction trace must hold the encoding and
+// the hash of the transaction of the block at the same index, and a receipt whose cumulative gas adds up the
+// gas used by the transactions, up to the gas used by the block. Hashes and receipts are only checked when
+// present, since older versions of edge don't record them. The parent state root of the trace must be the state
+// root of the parent block, when it is given. An error is returned for every inconsistency.
+func CheckTrace(block *types.Block, trace *types.Trace, parent *types.Header) []error {
+	var errs []error
+	if len(trace.TxnTraces) != len(block.Transactions) {
+		errs = append(errs, fmt.Errorf("the trace has %d transactions but the block has %d",
+			len(trace.TxnTraces), len(block.Transactions)))
+	}
+
+	var cumulativeGasUsed uint64
+	receipts := 0
+	for i, txnTrace := range trace.TxnTraces {
+		if i >= len(block.Transactions) {
+			break
+		}
+		tx := block.Transactions[i]
+		if !bytes.Equal(txnTrace.Transaction, tx.MarshalRLP()) {
+			errs = append(errs, fmt.Errorf("transactionTraces[%d].txn isn't the encoding of transaction %s", i, tx.Hash))
+		}
+		if txnTrace.Hash != types.ZeroHash && txnTrace.Hash != tx.Hash {
+			errs = append(errs, fmt.Errorf("transactionTraces[%d].hash is %s but the transaction hash is %s",
+				i, txnTrace.Hash, tx.Hash))
+		}
+
+		if len(txnTrace.Receipt) == 0 {
+			continue
+		}
+		receipts++
+		cumulativeGasUsed += txnTrace.GasUsed
+		var receipt types.Receipt
+		if err := receipt.UnmarshalRLP(txnTrace.Receipt); err != nil {
+			errs = append(errs, fmt.Errorf("transactionTraces[%d].receipt can't be decoded: %w", i, err))
+			continue
+		}
+		if receipt.CumulativeGasUsed != cumulativeGasUsed {
+			errs = append(errs, fmt.Errorf("transactionTraces[%d].receipt has a cumulative gas of %d but the "+
+				"transactions used %d", i, receipt.CumulativeGasUsed, cumulativeGasUsed))
+		}
+	}
+	if receipts == len(block.Transactions) && cumulativeGasUsed != block.Header.GasUsed {
+		errs = append(errs, fmt.Errorf("the transactions used %d gas but the block used %d",
+			cumulativeGasUsed, block.Header.GasUsed))
+	}
+
+	if parent != nil && trace.ParentStateRoot != parent.StateRoot {
+		errs = append(errs, fmt.Errorf("parentStateRoot is %s but the state root of block #%d is %s",
+			trace.ParentStateRoot, parent.Number, parent.StateRoot))
+	}
+	return errs
+}
+
+// Return the error of a field whose value doesn't match the one computed from the content of the block.
+func fieldError(field string, value, computed types.Hash) error {
+	return fmt.Errorf("%s is %s but %s is computed", field, value, computed)
+}
@@ -0,0 +1,219 @@
+package edge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Generate a random block of the given number of transactions along with its trace and its parent header.
+// The block and its header are copies, so that they can be altered.
+func generateTestBlock(t *testing.T, txs int) (*types.Block, *types.Trace, *types.Header) {
+	t.Helper()
+	generator, err := NewGenerator(GeneratorConfig{
+		First:   1,
+		ChainID: 2001,
+		Profile: Profiles[DefaultProfile],
+		Target:  Target{Txs: txs},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := generator.Block(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := generator.Block(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trace, err := generator.Trace(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactions := make([]*types.Transaction, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		transactions[i] = tx.Copy()
+	}
+	txnTraces := make([]*types.TxnTrace, len(trace.TxnTraces))
+	for i, txnTrace := range trace.TxnTraces {
+		txnTraceCopy := *txnTrace
+		txnTraces[i] = &txnTraceCopy
+	}
+	traceCopy := *trace
+	traceCopy.TxnTraces = txnTraces
+	return &types.Block{Header: block.Header.Copy(), Transactions: transactions, Uncles: block.Uncles},
+		&traceCopy, parent.Header.Copy()
+}
+
+// Check that every error matches one of the expected messages, and that every expected message is matched.
+func checkErrors(t *testing.T, errs []error, expected []string) {
+	t.Helper()
+	matched := make([]bool, len(expected))
+	for _, err := range errs {
+		found := false
+		for i, message := range expected {
+			if strings.Contains(err.Error(), message) {
+				matched[i], found = true, true
+			}
+		}
+		if !found {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	for i, message := range expected {
+		if !matched[i] {
+			t.Errorf("expected an error containing %q, got %v", message, errs)
+		}
+	}
+}
+
+// TestValidateBlock checks that altered transaction hashes, transactions roots and header hashes are reported,
+// on both sides of the 128 transactions from which the keys of the transactions trie take two bytes.
+func TestValidateBlock(t *testing.T) {
+	otherHash := types.StringToHash("0x01")
+	for _, test := range []struct {
+		name     string
+		txs      int
+		alter    func(block *types.Block)
+		expected []string
+	}{
+		{"valid", 10, func(block *types.Block) {}, nil},
+		{"valid above 128 transactions", 200, func(block *types.Block) {}, nil},
+		{
+			"transaction hash",
+			10,
+			func(block *types.Block) { block.Transactions[3].Hash = otherHash },
+			[]string{"transactions[3].hash is " + otherHash.String()},
+		},
+		{
+			"transaction hash above 128 transactions",
+			200,
+			func(block *types.Block) { block.Transactions[150].Hash = otherHash },
+			[]string{"transactions[150].hash is " + otherHash.String()},
+		},
+		{
+			"transactions root",
+			10,
+			func(block *types.Block) {
+				block.Header.TxRoot = otherHash
+				block.Header.ComputeHash()
+			},
+			[]string{"transactionsRoot is " + otherHash.String()},
+		},
+		{
+			"transactions root above 128 transactions",
+			200,
+			func(block *types.Block) {
+				block.Header.TxRoot = otherHash
+				block.Header.ComputeHash()
+			},
+			[]string{"transactionsRoot is " + otherHash.String()},
+		},
+		{
+			"transaction order above 128 transactions",
+			200,
+			func(block *types.Block) {
+				block.Transactions[10], block.Transactions[150] = block.Transactions[150], block.Transactions[10]
+			},
+			[]string{"transactionsRoot is "},
+		},
+		{
+			"header hash",
+			10,
+			func(block *types.Block) { block.Header.Hash = otherHash },
+			[]string{"hash is " + otherHash.String()},
+		},
+		{
+			"header field",
+			10,
+			func(block *types.Block) { block.Header.GasUsed++ },
+			[]string{"hash is "},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			block, _, _ := generateTestBlock(t, test.txs)
+			test.alter(block)
+			checkErrors(t, ValidateBlock(block), test.expected)
+		})
+	}
+}
+
+// TestCheckTrace checks that the inconsistencies between a trace and its block are reported.
+func TestCheckTrace(t *testing.T) {
+	otherHash := types.StringToHash("0x01")
+	for _, test := range []struct {
+		name     string
+		alter    func(block *types.Block, trace *types.Trace, parent *types.Header)
+		expected []string
+	}{
+		{"consistent", func(block *types.Block, trace *types.Trace, parent *types.Header) {}, nil},
+		{
+			"without hashes nor receipts",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				for _, txnTrace := range trace.TxnTraces {
+					txnTrace.Hash = types.ZeroHash
+					txnTrace.Receipt = nil
+				}
+			},
+			nil,
+		},
+		{
+			"missing transaction",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				trace.TxnTraces = trace.TxnTraces[:len(trace.TxnTraces)-1]
+			},
+			[]string{"the trace has 2 transactions but the block has 3"},
+		},
+		{
+			"transaction encoding",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				trace.TxnTraces[1].Transaction = trace.TxnTraces[0].Transaction
+			},
+			[]string{"transactionTraces[1].txn isn't the encoding"},
+		},
+		{
+			"transaction hash",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				trace.TxnTraces[1].Hash = otherHash
+			},
+			[]string{"transactionTraces[1].hash is " + otherHash.String()},
+		},
+		{
+			"invalid receipt",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				trace.TxnTraces[1].Receipt = []byte{0x01}
+			},
+			[]string{"transactionTraces[1].receipt can't be decoded"},
+		},
+		{
+			"gas used by a transaction",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				trace.TxnTraces[2].GasUsed++
+			},
+			[]string{"transactionTraces[2].receipt has a cumulative gas", "the transactions used"},
+		},
+		{
+			"gas used by the block",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				block.Header.GasUsed++
+			},
+			[]string{"the transactions used"},
+		},
+		{
+			"parent state root",
+			func(block *types.Block, trace *types.Trace, parent *types.Header) {
+				parent.StateRoot = otherHash
+			},
+			[]string{"parentStateRoot is"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			block, trace, parent := generateTestBlock(t, 3)
+			test.alter(block, trace, parent)
+			checkErrors(t, CheckTrace(block, trace, parent), test.expected)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package edge
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Wallet holds the pool of private keys signing the random transactions, along with the next nonce of
+// each account. Keys are deterministic: the key of the account `i` is the integer `i+1`, e.g. the first
+// account is `0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf`.
+type Wallet struct {
+	chainID *big.Int
+	signer  crypto.TxSigner
+	keys    []*ecdsa.PrivateKey
+	nonces  []uint64
+}
+
+// NewWallet returns a wallet of the given number of accounts, signing transactions for the given chain ID
+// with every fork enabled.
+func NewWallet(chainID uint64, size int) *Wallet {
+	w := &Wallet{
+		chainID: new(big.Int).SetUint64(chainID),
+		signer:  crypto.NewSigner(chain.AllForksEnabled.At(0), chainID),
+		keys:    make([]*ecdsa.PrivateKey, size),
+		nonces:  make([]uint64, size),
+	}
+	for i := range w.keys {
+		// Parsing 32 bytes never fails.
+		w.keys[i], _ = crypto.ParseECDSAPrivateKey(big.NewInt(int64(i + 1)).FillBytes(make([]byte, 32)))
+	}
+	return w
+}
+
+// Addresses returns the addresses of the accounts of the wallet.
+func (w *Wallet) Addresses() []types.Address {
+	addresses := make([]types.Address, len(w.keys))
+	for i, key := range w.keys {
+		addresses[i] = crypto.PubKeyToAddress(&key.PublicKey)
+	}
+	return addresses
+}
+
+// Sign signs the transaction with the key of a random account, using the next nonce of that account.
+// The chain ID and the sender of the transaction are set, and its hash is computed.
+func (w *Wallet) Sign(tx *types.Transaction) (*types.Transaction, error) {
+	i := generateRandomInt(int64(len(w.keys)))
+	tx.Nonce = w.nonces[i]
+	tx.ChainID = w.chainID
+	signed, err := w.signer.SignTx(tx, w.keys[i])
+	if err != nil {
+		return nil, err
+	}
+	w.nonces[i]++
+
+	signed.From, err = w.signer.Sender(signed)
+	if err != nil {
+		return nil, err
+	}
+	ComputeTxHash(signed)
+	return signed, nil
+}
@@ -0,0 +1,267 @@
+package edge
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// MissingPath is a path of the account trie or of a storage trie of a trace that can't be resolved through
+// the witness of the trace, because one of its nodes is missing.
+type MissingPath struct {
+	// Account whose path, or the path of one of whose storage slots, is missing.
+	Address types.Address
+	// Storage slot whose path is missing, nil when the path of the account itself is missing.
+	Slot *types.Hash
+	// Nibbles leading from the root of the trie to the missing node, and the hash of that node.
+	Path []byte
+	Node types.Hash
+}
+
+// String describes the missing path.
+func (m MissingPath) String() string {
+	path := "the root"
+	if len(m.Path) > 0 {
+		var nibbles strings.Builder
+		for _, nibble := range m.Path {
+			nibbles.WriteByte("0123456789abcdef"[nibble])
+		}
+		path = "path " + nibbles.String()
+	}
+	if m.Slot == nil {
+		return fmt.Sprintf("account %s: node %s missing at %s", m.Address, m.Node, path)
+	}
+	return fmt.Sprintf("slot %s of account %s: node %s missing at %s", *m.Slot, m.Address, m.Node, path)
+}
+
+// A node of a partial trie missing from a witness, along with the nibbles of the path leading to it.
+type missingNode struct {
+	path []byte
+	hash types.Hash
+}
+
+// VerifyWitness rebuilds the partial account and storage tries of a trace from its witness, made of the nodes
+// of the `AccountTrie` and `StorageTrie` maps, starting at its parent state root. It checks that every account
+// and storage slot written or read by the transactions, according to their journal entries, can be resolved
+// through the witness: either found, or proven absent. The paths that can't be resolved are returned, sorted
+// by account and slot. Storage slots of an account whose own path is missing aren't checked. An error is
+// returned when the witness holds malformed nodes.
+func VerifyWitness(trace *types.Trace) ([]MissingPath, error) {
+	accountNodes, err := decodeWitness(trace.AccountTrie)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account trie: %w", err)
+	}
+	storageNodes, err := decodeWitness(trace.StorageTrie)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage trie: %w", err)
+	}
+
+	// Gather the accounts and storage slots touched by the transactions.
+	slots := make(map[types.Address]map[types.Hash]struct{})
+	for _, txnTrace := range trace.TxnTraces {
+		for address, entry := range txnTrace.Delta {
+			if slots[address] == nil {
+				slots[address] = make(map[types.Hash]struct{})
+			}
+			for slot := range entry.Storage {
+				slots[address][slot] = struct{}{}
+			}
+			for slot := range entry.StorageRead {
+				slots[address][slot] = struct{}{}
+			}
+		}
+	}
+	addresses := make([]types.Address, 0, len(slots))
+	for address := range slots {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	var missingPaths []MissingPath
+	for _, address := range addresses {
+		value, missing, err := resolve(accountNodes, trace.ParentStateRoot, crypto.Keccak256(address[:]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve account %s: %w", address, err)
+		}
+		if missing != nil {
+			missingPaths = append(missingPaths, MissingPath{Address: address, Path: missing.path, Node: missing.hash})
+			continue
+		}
+		if value == nil {
+			// The account doesn't exist yet, nor does its storage.
+			continue
+		}
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return nil, fmt.Errorf("invalid account %s: %w", address, err)
+		}
+
+		accountSlots := make([]types.Hash, 0, len(slots[address]))
+		for slot := range slots[address] {
+			accountSlots = append(accountSlots, slot)
+		}
+		sort.Slice(accountSlots, func(i, j int) bool {
+			return bytes.Compare(accountSlots[i][:], accountSlots[j][:]) < 0
+		})
+		for _, slot := range accountSlots {
+			_, missing, err := resolve(storageNodes, account.Root, crypto.Keccak256(slot[:]))
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve slot %s of account %s: %w", slot, address, err)
+			}
+			if missing != nil {
+				slot := slot
+				missingPaths = append(missingPaths, MissingPath{
+					Address: address,
+					Slot:    &slot,
+					Path:    missing.path,
+					Node:    missing.hash,
+				})
+			}
+		}
+	}
+	return missingPaths, nil
+}
+
+// Decode the nodes of a witness, indexed by hash. Hashes and nodes are encoded in hex, with or without prefix.
+func decodeWitness(witness map[string]string) (map[types.Hash][]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(witness))
+	for key, value := range witness {
+		hash, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(hash) != types.HashLength {
+			return nil, fmt.Errorf("invalid node hash %q", key)
+		}
+		node, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid node %s: %w", key, err)
+		}
+		nodes[types.BytesToHash(hash)] = node
+	}
+	return nodes, nil
+}
+
+// Resolve the value of a key through the given nodes of a trie, starting at its root. The value is nil when
+// the key is proven absent from the trie. When a node of the path of the key is missing, it is returned instead.
+func resolve(nodes map[types.Hash][]byte, root types.Hash, key []byte) ([]byte, *missingNode, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil, nil
+	}
+
+	path := keyNibbles(key)
+	depth := 0
+	hash := root
+	var p fastrlp.Parser
+	for {
+		encoded, ok := nodes[hash]
+		if !ok {
+			return nil, &missingNode{path: append([]byte{}, path[:depth]...), hash: hash}, nil
+		}
+		node, err := p.Parse(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid node %s: %w", hash, err)
+		}
+
+		// Walk down the nodes embedded in their parent, until reaching a node referenced by its hash.
+		for {
+			if node.Type() != fastrlp.TypeArray {
+				return nil, nil, fmt.Errorf("invalid node under %s: expected a list, got %s", hash, node.Type())
+			}
+
+			var child *fastrlp.Value
+			switch node.Elems() {
+			case 17:
+				// Branch node.
+				if depth == len(path) {
+					return leafValue(node.Get(16))
+				}
+				child = node.Get(int(path[depth]))
+				depth++
+
+			case 2:
+				// Leaf or extension node.
+				compact, err := node.Get(0).Bytes()
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid node under %s: %w", hash, err)
+				}
+				nibbles, leaf := decodeCompact(compact)
+				if !bytes.HasPrefix(path[depth:], nibbles) {
+					return nil, nil, nil
+				}
+				depth += len(nibbles)
+				if leaf {
+					if depth != len(path) {
+						return nil, nil, nil
+					}
+					return leafValue(node.Get(1))
+				}
+				child = node.Get(1)
+
+			default:
+				return nil, nil, fmt.Errorf("invalid node under %s: unexpected list of %d items", hash, node.Elems())
+			}
+
+			switch child.Type() {
+			case fastrlp.TypeArray:
+				node = child
+				continue
+			case fastrlp.TypeBytes:
+				reference, err := child.Bytes()
+				if err == nil && len(reference) == 0 {
+					// Empty child, the key is absent.
+					return nil, nil, nil
+				}
+				if err != nil || len(reference) != types.HashLength {
+					return nil, nil, fmt.Errorf("invalid node under %s: invalid child reference", hash)
+				}
+				hash = types.BytesToHash(reference)
+			default:
+				// Empty child, the key is absent.
+				return nil, nil, nil
+			}
+			break
+		}
+	}
+}
+
+// Return a copy of the value held by a leaf or a branch node, nil when there is none.
+func leafValue(v *fastrlp.Value) ([]byte, *missingNode, error) {
+	if v.Type() != fastrlp.TypeBytes {
+		return nil, nil, nil
+	}
+	value, err := v.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	return append([]byte{}, value...), nil, nil
+}
+
+// Split a key of a trie into nibbles.
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+// Decode the compact encoding of the path of a leaf or extension node into nibbles, and return whether the
+// node is a leaf.
+func decodeCompact(compact []byte) ([]byte, bool) {
+	if len(compact) == 0 {
+		return nil, false
+	}
+	flag := compact[0] >> 4
+	var nibbles []byte
+	if flag&1 == 1 {
+		nibbles = append(nibbles, compact[0]&0x0f)
+	}
+	return append(nibbles, keyNibbles(compact[1:])...), flag >= 2
+}
@@ -0,0 +1,166 @@
+package edge
+
+import (
+	"archive/tar"
+	"compress/bzip2"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Dataset whose traces have complete witnesses, touching accounts and storage slots alike.
+const witnessArchive = "../../data/archives/mock-sstore-and-sha3.tar.bz2"
+
+// Read the traces of a `.tar.bz2` dataset archive.
+func readArchiveTraces(t *testing.T, archive string) []*types.Trace {
+	t.Helper()
+	f, err := os.Open(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var traces []*types.Trace
+	reader := tar.NewReader(bzip2.NewReader(f))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag != tar.TypeReg || path.Base(path.Dir(header.Name)) != "traces" {
+			continue
+		}
+		var trace types.Trace
+		if err := json.NewDecoder(reader).Decode(&trace); err != nil {
+			t.Fatalf("%s: %v", header.Name, err)
+		}
+		traces = append(traces, &trace)
+	}
+	if len(traces) == 0 {
+		t.Fatalf("no trace found in %s", archive)
+	}
+	return traces
+}
+
+// Return a copy of a witness without the node of the given hash.
+func withoutNode(witness map[string]string, removed string) map[string]string {
+	nodes := make(map[string]string, len(witness)-1)
+	for hash, node := range witness {
+		if hash != removed {
+			nodes[hash] = node
+		}
+	}
+	return nodes
+}
+
+// Return the hashes of the nodes of a witness, sorted so that the tests are deterministic.
+func witnessHashes(witness map[string]string) []string {
+	hashes := make([]string, 0, len(witness))
+	for hash := range witness {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// TestCompleteWitness checks that no path is missing from complete witnesses.
+func TestCompleteWitness(t *testing.T) {
+	for _, trace := range readArchiveTraces(t, witnessArchive) {
+		missingPaths, err := VerifyWitness(trace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(missingPaths) > 0 {
+			t.Errorf("witness of the trace at %s missing %s", trace.ParentStateRoot, missingPaths)
+		}
+	}
+}
+
+// TestMissingRoot checks that every account touched by a trace is reported when the root of the account trie
+// is removed from its witness.
+func TestMissingRoot(t *testing.T) {
+	trace := readArchiveTraces(t, witnessArchive)[0]
+	for key := range trace.AccountTrie {
+		if types.StringToHash(key) == trace.ParentStateRoot {
+			trace.AccountTrie = withoutNode(trace.AccountTrie, key)
+		}
+	}
+
+	missingPaths, err := VerifyWitness(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := make(map[types.Address]bool)
+	for _, txnTrace := range trace.TxnTraces {
+		for address := range txnTrace.Delta {
+			accounts[address] = true
+		}
+	}
+	if len(accounts) == 0 {
+		t.Fatal("no account touched by the trace")
+	}
+	if len(missingPaths) != len(accounts) {
+		t.Errorf("%d paths missing instead of %d: %s", len(missingPaths), len(accounts), missingPaths)
+	}
+	for _, missingPath := range missingPaths {
+		if !accounts[missingPath.Address] || missingPath.Slot != nil || len(missingPath.Path) != 0 ||
+			missingPath.Node != trace.ParentStateRoot {
+			t.Errorf("unexpected missing path: %s", missingPath)
+		}
+	}
+}
+
+// TestMissingNode checks that removing a node from the account or storage trie of a witness reports the paths
+// leading to that node, and only those.
+func TestMissingNode(t *testing.T) {
+	for _, storage := range []bool{false, true} {
+		name := "account"
+		if storage {
+			name = "storage"
+		}
+		t.Run(name, func(t *testing.T) {
+			reported := 0
+			for _, trace := range readArchiveTraces(t, witnessArchive) {
+				witness := trace.AccountTrie
+				if storage {
+					witness = trace.StorageTrie
+				}
+				for _, key := range witnessHashes(witness) {
+					removed := types.StringToHash(key)
+					if removed == trace.ParentStateRoot {
+						continue
+					}
+					partial := *trace
+					if storage {
+						partial.StorageTrie = withoutNode(witness, key)
+					} else {
+						partial.AccountTrie = withoutNode(witness, key)
+					}
+
+					missingPaths, err := VerifyWitness(&partial)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, missingPath := range missingPaths {
+						if missingPath.Node != removed || (missingPath.Slot != nil) != storage ||
+							(!storage && len(missingPath.Path) == 0) {
+							t.Errorf("node %s removed, unexpected missing path: %s", removed, missingPath)
+						}
+					}
+					reported += len(missingPaths)
+				}
+			}
+			if reported == 0 {
+				t.Errorf("no path reported missing after removing %s nodes", name)
+			}
+		})
+	}
+}
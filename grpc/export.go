@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"bytes"
+	"math"
+	pb "zero-provers/server/grpc/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Maximum size of the blocks sent in a single export event, same as edge.
+const exportMaxPayloadSize = 512 * 1024
+
+// Export is the implementation of the `Export` RPC method.
+// It streams the RLP-encoded blocks of the requested range, concatenated in chunks of at most
+// `exportMaxPayloadSize` bytes. The range ends at the head of the chain when `to` isn't set or is past the head.
+// Blocks missing from the dataset are skipped since datasets don't have to be contiguous. The export stops when
+// the client cancels the stream.
+func (s *server) Export(req *pb.ExportRequest, stream pb.System_ExportServer) error {
+	log.Info().Msgf("gRPC /Export request received from block #%d to block #%d", req.From, req.To)
+
+	head, _, err := getHead()
+	if err != nil {
+		return err
+	}
+	first, err := getFirstNumber()
+	if err != nil {
+		return err
+	}
+	to := head
+	if req.To != 0 {
+		if req.From >= req.To {
+			return status.Error(codes.InvalidArgument, "to must be greater than from")
+		}
+		to = min(req.To, head)
+	}
+
+	var chunk bytes.Buffer
+	var chunkFrom, chunkTo uint64
+	flush := func() error {
+		if chunk.Len() == 0 {
+			return nil
+		}
+		log.Debug().Msgf("Exporting blocks #%d to #%d (%d bytes)", chunkFrom, chunkTo, chunk.Len())
+		if err := stream.Send(&pb.ExportEvent{
+			From:   chunkFrom,
+			To:     chunkTo,
+			Latest: head,
+			Data:   chunk.Bytes(),
+		}); err != nil {
+			return err
+		}
+		chunk.Reset()
+		return nil
+	}
+
+	for number := max(req.From, first); number <= to; number++ {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+		if _, encodedBlock, err := getBlock(number); err == nil {
+			if chunk.Len()+len(encodedBlock) >= exportMaxPayloadSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if chunk.Len() == 0 {
+				chunkFrom = number
+			}
+			chunk.Write(encodedBlock)
+			chunkTo = number
+		}
+		// Stop before the block number overflows.
+		if number == math.MaxUint64 {
+			break
+		}
+	}
+	return flush()
+}
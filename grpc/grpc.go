@@ -4,14 +4,11 @@ package grpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
-	"os"
-	"path/filepath"
-	"sort"
-	"strconv"
-	"strings"
 	"sync"
+	"zero-provers/server/chain"
 	"zero-provers/server/grpc/edge"
 	pb "zero-provers/server/grpc/pb"
 	"zero-provers/server/logger"
@@ -22,7 +19,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 // Constant dummy block height returned by the `/GetStatus` endpoint.
@@ -40,6 +39,13 @@ var (
 	// sends those requests, in order to be aware of new blocks and to start proving as soon as possible.
 	requestCounter int
 	lock           sync.RWMutex
+
+	// Generator of the random blocks, used in random mode.
+	generator *edge.Generator
+
+	// Events published when the block number changes in random mode, along with the last block number.
+	randomFeed       chain.Feed
+	lastRandomHeight uint64
 )
 
 type ServerConfig struct {
@@ -48,15 +54,22 @@ type ServerConfig struct {
 	Mode                       modes.Mode
 	UpdateDataThreshold        int
 	UpdateBlockNumberThreshold int
-	MockData                   MockData
-}
-
-// Mock data config.
-type MockData struct {
-	BlockDir  string
-	TraceDir  string
-	BlockFile string
-	TraceFile string
+	// Chain made of the mock data, served in every mode but random.
+	Chain *chain.Chain
+	// Chain ID reported by the `/GetStatus` endpoint.
+	ChainID int64
+	// Genesis hash reported by the `/GetStatus` endpoint, the parent hash of the first block when nil.
+	GenesisHash *types.Hash
+
+	// Seed of the random data, used in `random` mode.
+	Seed int64
+	// Profile and target shaping the random blocks and traces, used in `random` mode.
+	RandomProfile edge.Profile
+	RandomTarget  edge.Target
+	// Number of consecutive heights the random target is stepped through, used in `random` mode.
+	SweepSteps int
+	// Run the random transactions through the edge EVM, used in `random` mode.
+	ExecuteRandom bool
 }
 
 // server is an internal implementation of the gRPC server.
@@ -77,6 +90,29 @@ func StartgRPCServer(_config ServerConfig) error {
 	}
 	log = logger.NewLogger(lc)
 
+	// Set up the random block generator.
+	if config.Mode == modes.RandomMode {
+		log.Info().Msgf("Generating random data with seed %d", config.Seed)
+		edge.SetSeed(config.Seed)
+		if config.ExecuteRandom && (config.RandomTarget.AccountTrieNodes > 0 ||
+			config.RandomTarget.StorageTrieNodes > 0 || config.RandomTarget.TraceBytes > 0) {
+			log.Warn().Msg("Trie and trace size targets are ignored when executing the random transactions")
+		}
+
+		var err error
+		generator, err = edge.NewGenerator(edge.GeneratorConfig{
+			First:      constantBlockHeight,
+			ChainID:    uint64(config.ChainID),
+			Profile:    config.RandomProfile,
+			Target:     config.RandomTarget,
+			SweepSteps: config.SweepSteps,
+			Execute:    config.ExecuteRandom,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create a listener on the specified port.
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Port))
 	if err != nil {
@@ -107,237 +143,231 @@ func (s *server) GetStatus(context.Context, *empty.Empty) (*pb.ChainStatus, erro
 	log.Debug().Msgf("Request counter: %d", requestCounter)
 	lock.Unlock()
 
-	// Load block number from file or increment block number based on the request counter.
-	var height int64
+	// Move the head of the chain in dynamic and random modes, based on the request counter.
 	switch config.Mode {
-	case modes.StaticMode:
-		// Parse the block mock file and return the header number.
-		var err error
-		height, err = getBlockNumberFromBlockFile(config.MockData.BlockFile)
-		if err != nil {
-			return nil, err
-		}
-
 	case modes.DynamicMode:
-		// List and sort the block mock files under the block mock directory.
-		files, err := getFilesInDir(config.MockData.BlockDir)
-		if err != nil {
-			return nil, err
-		}
-
-		// Parse the block mock file at the current index and return the header number.
 		lock.RLock()
-		fileIndex := computeIndex(requestCounter, config.UpdateDataThreshold, len(files))
+		position := (requestCounter - 1) / config.UpdateDataThreshold
 		lock.RUnlock()
-		file := files[fileIndex]
-		height, err = getBlockNumberFromBlockFile(file)
-		if err != nil {
-			return nil, err
-		}
+		config.Chain.SetPosition(position)
 
 	case modes.RandomMode:
-		// Increment the constant block number based on request counter.
-		lock.RLock()
-		height = int64(constantBlockHeight + requestCounter%config.UpdateBlockNumberThreshold)
-		lock.RUnlock()
+		// The event is published while the lock is held, so that concurrent requests publish their events in
+		// the order the block number changes.
+		lock.Lock()
+		height := randomHeight()
+		if lastRandomHeight != 0 && height != lastRandomHeight {
+			publishRandomEvent(lastRandomHeight, height)
+		}
+		lastRandomHeight = height
+		lock.Unlock()
+	}
 
-	default:
-		return nil, errWrongMode
+	// Load the head of the chain from the dataset or from the random blocks.
+	// The head never moves in static mode, it is moved by the block producer in timed and replay modes,
+	// and by the control API in manual mode.
+	height, hash, err := getHead()
+	if err != nil {
+		return nil, err
+	}
+	genesis, err := getGenesisHash()
+	if err != nil {
+		return nil, err
 	}
 
 	log.Debug().Msgf("StatusResponse number: %v", height)
 	return &pb.ChainStatus{
+		Network: config.ChainID,
+		Genesis: genesis.String(),
 		Current: &pb.ChainStatus_Block{
-			Number: height,
+			Number: int64(height),
+			Hash:   hash.String(),
 		},
+		P2PAddr: p2pAddr,
 	}, nil
-
 }
 
 // BlockByNumber is the implementation of the `BlockByNumber` RPC method.
-func (s *server) BlockByNumber(context.Context, *pb.BlockNumber) (*pb.BlockData, error) {
-	log.Info().Msg("gRPC /BlockByNumber request received")
+func (s *server) BlockByNumber(_ context.Context, req *pb.BlockNumber) (*pb.BlockData, error) {
+	log.Info().Msgf("gRPC /BlockByNumber request received for block #%d", req.Number)
+
+	block, encodedBlock, err := getBlock(req.Number)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Msgf("Decoded block header: %+v", *block.Header)
+	log.Debug().Msgf("Number of transactions: %d", len(block.Transactions))
+	for i, tx := range block.Transactions {
+		log.Debug().Msgf("Tx #%d: %+v", i, tx)
+	}
+	log.Debug().Msgf("Number of uncles: %d", len(block.Uncles))
+	for i, uncle := range block.Uncles {
+		log.Debug().Msgf("Uncle #%d: %+v", i, uncle)
+	}
+
+	return &pb.BlockData{
+		Data: encodedBlock,
+	}, nil
+}
 
-	// Load block data from file or generate random data.
-	var block *types.Block
+// GetTrace is the implementation of the `GetTrace` RPC method.
+func (s *server) GetTrace(_ context.Context, req *pb.BlockNumber) (*pb.Trace, error) {
+	log.Info().Msgf("gRPC /GetTrace request received for block #%d", req.Number)
+
+	// Load trace data from the dataset or generate random data.
+	var encodedTrace []byte
 	switch config.Mode {
-	case modes.StaticMode:
-		// Parse the block mock file in the edge RPC format and convert it to the GRPC format.
-		var mockBlockRPC edge.BlockRPC
-		if err := loadDataFromFile(config.MockData.BlockFile, &mockBlockRPC); err != nil {
-			return nil, err
+	case modes.StaticMode, modes.DynamicMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode:
+		entry, ok := config.Chain.Get(req.Number)
+		if !ok {
+			return nil, errBlockNotFound(req.Number)
 		}
-		block = mockBlockRPC.ToBlockGrpc()
+		log.Trace().Msgf("Decoded trace: %+v", *entry.Trace)
+		encodedTrace = entry.EncodedTrace
 
-	case modes.DynamicMode:
-		// List and sort the block mock files under the block mock directory.
-		files, err := getFilesInDir(config.MockData.BlockDir)
+	case modes.RandomMode:
+		trace, err := generator.Trace(req.Number)
 		if err != nil {
-			return nil, err
+			return nil, errRandomBlock(req.Number, err)
 		}
+		log.Trace().Msgf("Decoded trace: %+v", *trace)
 
-		// Parse the block mock file at the current index and convert it to the GRPC format.
-		lock.RLock()
-		fileIndex := computeIndex(requestCounter, config.UpdateDataThreshold, len(files))
-		lock.RUnlock()
-		file := files[fileIndex]
-		var mockBlockRPC edge.BlockRPC
-		if err := loadDataFromFile(file, &mockBlockRPC); err != nil {
+		// Encode the trace using JSON.
+		encodedTrace, err = json.Marshal(trace)
+		if err != nil {
+			log.Error().Err(err).Msg("Trace encoding failed")
 			return nil, err
 		}
-		block = mockBlockRPC.ToBlockGrpc()
-
-	case modes.RandomMode:
-		// Return a random block data.
-		lock.RLock()
-		height := uint64(constantBlockHeight + requestCounter%config.UpdateBlockNumberThreshold)
-		lock.RUnlock()
-		txnTracesAmount := uint64(10)
-		block = edge.GenerateRandomEdgeBlock(height, txnTracesAmount)
 
 	default:
 		return nil, errWrongMode
 	}
-	if block != nil {
-		log.Debug().Msgf("Decoded block header: %+v", *block.Header)
-		log.Debug().Msgf("Number of transactions: %d", len(block.Transactions))
-		for i, tx := range block.Transactions {
-			log.Debug().Msgf("Tx #%d: %+v", i, tx)
-		}
-		log.Debug().Msgf("Number of uncles: %d", len(block.Uncles))
-		for i, uncle := range block.Uncles {
-			log.Debug().Msgf("Uncle #%d: %+v", i, uncle)
-		}
-	}
 
-	// Encode the block using RLP.
-	encodedBlock := block.MarshalRLP()
-	return &pb.BlockData{
-		Data: encodedBlock,
+	return &pb.Trace{
+		Trace: encodedTrace,
 	}, nil
 }
 
-func (s *server) GetTrace(context.Context, *pb.BlockNumber) (*pb.Trace, error) {
-	log.Info().Msg("gRPC /GetTrace request received")
-
-	// Load trace data from file or generate random data.
-	var trace types.Trace
+// Load the block of the given number from the dataset or generate random data.
+// The block is returned along with its RLP encoding.
+func getBlock(number uint64) (*types.Block, []byte, error) {
 	switch config.Mode {
-	case modes.StaticMode:
-		// Parse the decoded trace mock file.
-		if err := loadDataFromFile(config.MockData.TraceFile, &trace); err != nil {
-			return nil, err
+	case modes.StaticMode, modes.DynamicMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode:
+		entry, ok := config.Chain.Get(number)
+		if !ok {
+			return nil, nil, errBlockNotFound(number)
 		}
+		return entry.Block, entry.EncodedBlock, nil
 
-	case modes.DynamicMode:
-		// List the block trace files under the trace mock directory.
-		files, err := getFilesInDir(config.MockData.TraceDir)
+	case modes.RandomMode:
+		// Return a random block data encoded using RLP.
+		block, err := generator.Block(number)
 		if err != nil {
-			return nil, err
+			return nil, nil, errRandomBlock(number, err)
 		}
+		return block, block.MarshalRLP(), nil
 
-		// Parse the decoded trace mock file at the current index.
+	default:
+		return nil, nil, errWrongMode
+	}
+}
+
+// Return the number and hash of the block at the head of the chain, without moving it.
+func getHead() (uint64, types.Hash, error) {
+	switch config.Mode {
+	case modes.StaticMode, modes.DynamicMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode:
+		head := config.Chain.Head()
+		return head.Number, head.Block.Hash(), nil
+
+	case modes.RandomMode:
 		lock.RLock()
-		fileIndex := computeIndex(requestCounter, config.UpdateDataThreshold, len(files))
+		height := randomHeight()
 		lock.RUnlock()
-		file := files[fileIndex]
-		if err := loadDataFromFile(file, &trace); err != nil {
-			return nil, err
+		block, err := generator.Block(height)
+		if err != nil {
+			return 0, types.ZeroHash, errRandomBlock(height, err)
 		}
-
-	case modes.RandomMode:
-		trace = *edge.GenerateRandomEdgeTrace(10, 10, 10, 10)
+		return height, block.Hash(), nil
 
 	default:
-		return nil, errWrongMode
+		return 0, types.ZeroHash, errWrongMode
 	}
-	log.Trace().Msgf("Decoded trace: %+v", trace)
+}
 
-	// Encode the trace using base64.
-	encodedTrace, err := json.Marshal(trace)
-	if err != nil {
-		log.Error().Err(err).Msg("Trace encoding failed")
-		return nil, err
-	}
-	return &pb.Trace{
-		Trace: encodedTrace,
-	}, nil
+// Return the number of the block at the head of the chain in random mode, the lock being held by the caller.
+func randomHeight() uint64 {
+	return uint64(constantBlockHeight + requestCounter%config.UpdateBlockNumberThreshold)
 }
 
-// Compute the file index in the case of dynamic mode.
-// Iterate over all the indexes and if the index is greater than the number of files, return
-// the index of the last file.
-func computeIndex(counter, updateThreshold int, numberOfFiles int) int {
-	index := (counter - 1) / updateThreshold
-	if index > numberOfFiles-1 {
-		return numberOfFiles - 1
+// Return the number of the first block of the chain.
+func getFirstNumber() (uint64, error) {
+	switch config.Mode {
+	case modes.StaticMode, modes.DynamicMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode:
+		return config.Chain.First().Number, nil
+
+	case modes.RandomMode:
+		return generator.First(), nil
+
+	default:
+		return 0, errWrongMode
 	}
-	return index
 }
 
-// Load data from file.
-func loadDataFromFile(filePath string, target interface{}) error {
-	log.Debug().Msgf("Fetching mock data from %s", filePath)
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("error reading mock file: %w", err)
+// Return the genesis hash of the chain, either set in the config or the parent hash of the first block.
+func getGenesisHash() (types.Hash, error) {
+	if config.GenesisHash != nil {
+		return *config.GenesisHash, nil
 	}
 
-	if err := json.Unmarshal(data, target); err != nil {
-		return fmt.Errorf("error unmarshaling mock JSON: %w", err)
+	switch config.Mode {
+	case modes.StaticMode, modes.DynamicMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode:
+		return config.Chain.First().Block.ParentHash(), nil
+
+	case modes.RandomMode:
+		return generator.Genesis(), nil
+
+	default:
+		return types.ZeroHash, errWrongMode
 	}
+}
 
-	log.Debug().Msgf("Mock data loaded from %s", filePath)
-	return nil
+// Publish the event describing a move of the block number between two heights in random mode, the lock being
+// held by the caller. Random blocks are generated once and kept, so the removed headers are the ones previously
+// published.
+func publishRandomEvent(from, to uint64) {
+	var event chain.Event
+	if to > from {
+		for number := from + 1; number <= to; number++ {
+			event.Added = append(event.Added, randomHeader(number))
+		}
+	} else {
+		for number := from; number > to; number-- {
+			event.Removed = append(event.Removed, randomHeader(number))
+		}
+		event.Added = []chain.Header{randomHeader(to)}
+	}
+	randomFeed.Publish(event)
 }
 
-// Return file names in directory and sorted in natural order.
-func getFilesInDir(dirPath string) ([]string, error) {
-	files, err := filepath.Glob(filepath.Join(dirPath, "*.json"))
+// Return the header of the random block of the given number, listed in the events.
+func randomHeader(number uint64) chain.Header {
+	block, err := generator.Block(number)
 	if err != nil {
-		return nil, err
+		log.Error().Err(err).Msgf("Unable to generate block #%d", number)
+		return chain.Header{Number: number}
 	}
-
-	sort.Slice(files, func(i, j int) bool {
-		return naturalSort(files[i], files[j])
-	})
-
-	return files, nil
+	return chain.Header{Number: number, Hash: block.Hash()}
 }
 
-// Load block number from block file.
-func getBlockNumberFromBlockFile(filePath string) (int64, error) {
-	var mockBlockRPC edge.BlockRPC
-	if err := loadDataFromFile(filePath, &mockBlockRPC); err != nil {
-		return 0, err
-	}
-	return int64(mockBlockRPC.Number), nil
+// Return a gRPC `NotFound` error for the given block number.
+func errBlockNotFound(number uint64) error {
+	return status.Errorf(codes.NotFound, "block #%d not found", number)
 }
 
-// Sort files in natural order.
-func naturalSort(s1, s2 string) bool {
-	parts1 := strings.FieldsFunc(s1, func(r rune) bool {
-		return !((r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'))
-	})
-	parts2 := strings.FieldsFunc(s2, func(r rune) bool {
-		return !((r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'))
-	})
-
-	for i := 0; i < len(parts1) && i < len(parts2); i++ {
-		part1, part2 := parts1[i], parts2[i]
-		if part1 != part2 {
-			isDigit1 := part1[0] >= '0' && part1[0] <= '9'
-			isDigit2 := part2[0] >= '0' && part2[0] <= '9'
-
-			if isDigit1 && isDigit2 {
-				num1, _ := strconv.Atoi(part1)
-				num2, _ := strconv.Atoi(part2)
-				return num1 < num2
-			}
-
-			return part1 < part2
-		}
+// Return the error of a random block that couldn't be generated.
+func errRandomBlock(number uint64, err error) error {
+	if errors.Is(err, edge.ErrOutOfRange) {
+		return errBlockNotFound(number)
 	}
-
-	return len(parts1) < len(parts2)
+	return status.Errorf(codes.Internal, "unable to generate block #%d: %v", number, err)
 }
@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"zero-provers/server/chain"
+	"zero-provers/server/dataset"
+	"zero-provers/server/grpc/edge"
+	pb "zero-provers/server/grpc/pb"
+	"zero-provers/server/modes"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// TestStatusMatchesServedBlocks checks, for every block of every archive, that the hash reported by
+// `GetStatus` is the hash of the header served by `BlockByNumber`.
+func TestStatusMatchesServedBlocks(t *testing.T) {
+	archives, err := filepath.Glob("../data/archives/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) == 0 {
+		t.Fatal("no archive found")
+	}
+
+	for _, archive := range archives {
+		t.Run(filepath.Base(archive), func(t *testing.T) {
+			store, err := dataset.NewStore(dataset.Config{Archive: archive})
+			if err != nil {
+				// The traces of some archives can't be paired, see the dataset tests.
+				t.Skipf("unable to load the archive: %v", err)
+			}
+			config = ServerConfig{
+				Mode:  modes.ManualMode,
+				Chain: chain.New(chain.Config{Dataset: store, OnDatasetEnd: chain.HoldAction}),
+			}
+
+			index := store.Index()
+			for i := 0; i < index.Len(); i++ {
+				number := index.At(i).Number
+				if _, err := config.Chain.SetHead(number); err != nil {
+					t.Fatal(err)
+				}
+				status, err := (&server{}).GetStatus(context.Background(), nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, err := (&server{}).BlockByNumber(context.Background(), &pb.BlockNumber{Number: number})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var block types.Block
+				if err := block.UnmarshalRLP(data.Data); err != nil {
+					t.Fatalf("block #%d: %v", number, err)
+				}
+				if hash := block.Header.Copy().ComputeHash().Hash.String(); hash != status.Current.Hash {
+					t.Errorf("block #%d: status reports %s but the served header hashes to %s",
+						number, status.Current.Hash, hash)
+				}
+			}
+		})
+	}
+}
+
+// TestRandomEventsInOrder checks that the events published by concurrent `GetStatus` requests in random mode
+// follow each other, each one starting at the block number the previous one ended at.
+func TestRandomEventsInOrder(t *testing.T) {
+	config = ServerConfig{Mode: modes.RandomMode, UpdateBlockNumberThreshold: 4}
+	requestCounter, lastRandomHeight = 0, 0
+	var err error
+	generator, err = edge.NewGenerator(edge.GeneratorConfig{
+		First:   constantBlockHeight,
+		Profile: edge.Profiles[edge.DefaultProfile],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, unsubscribe := randomFeed.Subscribe()
+	defer unsubscribe()
+
+	// Every request but the first one moves the block number at most once, stay below the number of events
+	// buffered for a subscriber so that none is dropped.
+	const goroutines, requests = 12, 5
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < requests; j++ {
+				if _, err := (&server{}).GetStatus(context.Background(), nil); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The first request sets the initial block number without publishing any event.
+	previous := uint64(constantBlockHeight + 1)
+	for received := 0; ; received++ {
+		select {
+		case event := <-events:
+			from := event.Added[0].Number - 1
+			if len(event.Removed) > 0 {
+				from = event.Removed[0].Number
+			}
+			if from != previous {
+				t.Fatalf("event %d starts at block #%d instead of block #%d: %+v", received, from, previous, event)
+			}
+			previous = event.Added[len(event.Added)-1].Number
+		default:
+			if received == 0 {
+				t.Fatal("no event published")
+			}
+			return
+		}
+	}
+}
@@ -27,8 +27,18 @@ type SystemClient interface {
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ChainStatus, error)
 	// GetTrace returns the trace for a given block height.
 	GetTrace(ctx context.Context, in *BlockNumber, opts ...grpc.CallOption) (*Trace, error)
+	// PeersAdd adds a new peer.
+	PeersAdd(ctx context.Context, in *PeersAddRequest, opts ...grpc.CallOption) (*PeersAddResponse, error)
+	// PeersList returns the list of peers.
+	PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error)
+	// PeersStatus returns the info of a peer.
+	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error)
+	// Subscribe subscribes to blockchain events.
+	Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error)
 	// BlockByNumber returns blockchain data for a given block number.
 	BlockByNumber(ctx context.Context, in *BlockNumber, opts ...grpc.CallOption) (*BlockData, error)
+	// Export returns blockchain data for a range of block numbers.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
 }
 
 type systemClient struct {
@@ -57,6 +67,65 @@ func (c *systemClient) GetTrace(ctx context.Context, in *BlockNumber, opts ...gr
 	return out, nil
 }
 
+func (c *systemClient) PeersAdd(ctx context.Context, in *PeersAddRequest, opts ...grpc.CallOption) (*PeersAddResponse, error) {
+	out := new(PeersAddResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error) {
+	out := new(PeersListResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error) {
+	out := new(Peer)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[0], "/v1.System/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_SubscribeClient interface {
+	Recv() (*BlockchainEvent, error)
+	grpc.ClientStream
+}
+
+type systemSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemSubscribeClient) Recv() (*BlockchainEvent, error) {
+	m := new(BlockchainEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *systemClient) BlockByNumber(ctx context.Context, in *BlockNumber, opts ...grpc.CallOption) (*BlockData, error) {
 	out := new(BlockData)
 	err := c.cc.Invoke(ctx, "/v1.System/BlockByNumber", in, out, opts...)
@@ -66,6 +135,38 @@ func (c *systemClient) BlockByNumber(ctx context.Context, in *BlockNumber, opts
 	return out, nil
 }
 
+func (c *systemClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[1], "/v1.System/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_ExportClient interface {
+	Recv() (*ExportEvent, error)
+	grpc.ClientStream
+}
+
+type systemExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemExportClient) Recv() (*ExportEvent, error) {
+	m := new(ExportEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -74,8 +175,18 @@ type SystemServer interface {
 	GetStatus(context.Context, *emptypb.Empty) (*ChainStatus, error)
 	// GetTrace returns the trace for a given block height.
 	GetTrace(context.Context, *BlockNumber) (*Trace, error)
+	// PeersAdd adds a new peer.
+	PeersAdd(context.Context, *PeersAddRequest) (*PeersAddResponse, error)
+	// PeersList returns the list of peers.
+	PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error)
+	// PeersStatus returns the info of a peer.
+	PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error)
+	// Subscribe subscribes to blockchain events.
+	Subscribe(*emptypb.Empty, System_SubscribeServer) error
 	// BlockByNumber returns blockchain data for a given block number.
 	BlockByNumber(context.Context, *BlockNumber) (*BlockData, error)
+	// Export returns blockchain data for a range of block numbers.
+	Export(*ExportRequest, System_ExportServer) error
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -89,9 +200,24 @@ func (UnimplementedSystemServer) GetStatus(context.Context, *emptypb.Empty) (*Ch
 func (UnimplementedSystemServer) GetTrace(context.Context, *BlockNumber) (*Trace, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTrace not implemented")
 }
+func (UnimplementedSystemServer) PeersAdd(context.Context, *PeersAddRequest) (*PeersAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersAdd not implemented")
+}
+func (UnimplementedSystemServer) PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersList not implemented")
+}
+func (UnimplementedSystemServer) PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
+}
+func (UnimplementedSystemServer) Subscribe(*emptypb.Empty, System_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockNumber) (*BlockData, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlockByNumber not implemented")
 }
+func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -141,6 +267,81 @@ func _System_GetTrace_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_PeersAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersAdd(ctx, req.(*PeersAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersStatus(ctx, req.(*PeersStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).Subscribe(m, &systemSubscribeServer{stream})
+}
+
+type System_SubscribeServer interface {
+	Send(*BlockchainEvent) error
+	grpc.ServerStream
+}
+
+type systemSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemSubscribeServer) Send(m *BlockchainEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _System_BlockByNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockNumber)
 	if err := dec(in); err != nil {
@@ -159,6 +360,27 @@ func _System_BlockByNumber_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).Export(m, &systemExportServer{stream})
+}
+
+type System_ExportServer interface {
+	Send(*ExportEvent) error
+	grpc.ServerStream
+}
+
+type systemExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemExportServer) Send(m *ExportEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -174,11 +396,34 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTrace",
 			Handler:    _System_GetTrace_Handler,
 		},
+		{
+			MethodName: "PeersAdd",
+			Handler:    _System_PeersAdd_Handler,
+		},
+		{
+			MethodName: "PeersList",
+			Handler:    _System_PeersList_Handler,
+		},
+		{
+			MethodName: "PeersStatus",
+			Handler:    _System_PeersStatus_Handler,
+		},
 		{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _System_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _System_Export_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "grpc/pb/server.proto",
 }
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	pb "zero-provers/server/grpc/pb"
+
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// Validation rules of the peer requests, taken from the edge proto file.
+	peerAddrRegexp = regexp.MustCompile(`^\/[A-Za-z0-9._~-]+(\/[A-Za-z0-9._~-]+)*$`)
+	peerIDRegexp   = regexp.MustCompile(`^[A-Za-z0-9]{1,}$`)
+
+	// Address of the mock node, reported by the `/GetStatus` endpoint.
+	p2pAddr = "/ip4/127.0.0.1/tcp/1478/p2p/16Uiu2HAmQBzjRsBBBiDHmHvpXeCWRnzeyCmGG9tsRmPCAnZF9t4h"
+
+	// Protocols supported by an edge node.
+	peerProtocols = []string{"/id/0.1", "/disc/0.1", "/syncer/0.2", "/ibft/0.2"}
+
+	// Mock peers of the node. Peers added using the `PeersAdd` RPC method are appended to the list.
+	peers = []*pb.Peer{
+		{
+			Id:        "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW",
+			Protocols: peerProtocols,
+			Addrs:     []string{"/ip4/127.0.0.1/tcp/10001"},
+		},
+		{
+			Id:        "16Uiu2HAmS9Nq4QAaEiogE4ieJFUYsoH28magT7wSvJPpfUGBj3Hq",
+			Protocols: peerProtocols,
+			Addrs:     []string{"/ip4/127.0.0.1/tcp/20001"},
+		},
+		{
+			Id:        "16Uiu2HAm1kVEKsGQEPLvvd5DeUgXBBKrhTmBPHhMaTMc3Lg4yHk6",
+			Protocols: peerProtocols,
+			Addrs:     []string{"/ip4/127.0.0.1/tcp/30001"},
+		},
+	}
+	peersLock sync.RWMutex
+)
+
+// PeersAdd is the implementation of the `PeersAdd` RPC method.
+// The peer is added to the list of mock peers, no connection is made.
+func (s *server) PeersAdd(_ context.Context, req *pb.PeersAddRequest) (*pb.PeersAddResponse, error) {
+	log.Info().Msgf("gRPC /PeersAdd request received for peer %s", req.Id)
+
+	// The multiaddress must end with the peer ID, e.g. `/ip4/127.0.0.1/tcp/1478/p2p/<id>`.
+	addr, id, found := strings.Cut(req.Id, "/p2p/")
+	if !peerAddrRegexp.MatchString(req.Id) || !found || !peerIDRegexp.MatchString(id) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid peer address %s", req.Id)
+	}
+
+	peersLock.Lock()
+	defer peersLock.Unlock()
+	if findPeer(id) == nil {
+		peers = append(peers, &pb.Peer{
+			Id:        id,
+			Protocols: peerProtocols,
+			Addrs:     []string{addr},
+		})
+	}
+	return &pb.PeersAddResponse{
+		Message: "Peer address marked ready for dialing",
+	}, nil
+}
+
+// PeersList is the implementation of the `PeersList` RPC method.
+func (s *server) PeersList(context.Context, *empty.Empty) (*pb.PeersListResponse, error) {
+	log.Info().Msg("gRPC /PeersList request received")
+
+	peersLock.RLock()
+	defer peersLock.RUnlock()
+	return &pb.PeersListResponse{
+		Peers: append([]*pb.Peer{}, peers...),
+	}, nil
+}
+
+// PeersStatus is the implementation of the `PeersStatus` RPC method.
+func (s *server) PeersStatus(_ context.Context, req *pb.PeersStatusRequest) (*pb.Peer, error) {
+	log.Info().Msgf("gRPC /PeersStatus request received for peer %s", req.Id)
+
+	if !peerIDRegexp.MatchString(req.Id) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid peer ID %s", req.Id)
+	}
+
+	peersLock.RLock()
+	defer peersLock.RUnlock()
+	peer := findPeer(req.Id)
+	if peer == nil {
+		return nil, status.Errorf(codes.NotFound, "peer %s not found", req.Id)
+	}
+	return peer, nil
+}
+
+// Return the mock peer of the given ID, nil if there is none.
+func findPeer(id string) *pb.Peer {
+	for _, peer := range peers {
+		if peer.Id == id {
+			return peer
+		}
+	}
+	return nil
+}
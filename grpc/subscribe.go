@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"zero-provers/server/chain"
+	pb "zero-provers/server/grpc/pb"
+	"zero-provers/server/modes"
+
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Subscribe is the implementation of the `Subscribe` RPC method.
+// It sends the head of the chain, and then pushes an event every time the head moves, whatever the mode.
+func (s *server) Subscribe(_ *empty.Empty, stream pb.System_SubscribeServer) error {
+	log.Info().Msg("gRPC /Subscribe request received")
+
+	var events <-chan chain.Event
+	var unsubscribe func()
+	if config.Mode == modes.RandomMode {
+		events, unsubscribe = randomFeed.Subscribe()
+	} else {
+		events, unsubscribe = config.Chain.Subscribe()
+	}
+	defer unsubscribe()
+
+	// Send the head first, so that subscribers don't have to wait for the next block.
+	number, hash, err := getHead()
+	if err != nil {
+		return err
+	}
+	head := chain.Event{Added: []chain.Header{{Number: number, Hash: hash}}}
+	if err := sendEvent(stream, head); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Info().Msg("gRPC /Subscribe stream closed")
+			return nil
+		case event := <-events:
+			if err := sendEvent(stream, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Send a head event on a `Subscribe` stream.
+func sendEvent(stream pb.System_SubscribeServer, event chain.Event) error {
+	pbEvent := &pb.BlockchainEvent{
+		Added:   []*pb.BlockchainEvent_Header{},
+		Removed: []*pb.BlockchainEvent_Header{},
+	}
+	for _, header := range event.Added {
+		pbEvent.Added = append(pbEvent.Added, &pb.BlockchainEvent_Header{
+			Number: int64(header.Number),
+			Hash:   header.Hash.String(),
+		})
+	}
+	for _, header := range event.Removed {
+		pbEvent.Removed = append(pbEvent.Removed, &pb.BlockchainEvent_Header{
+			Number: int64(header.Number),
+			Hash:   header.Hash.String(),
+		})
+	}
+
+	log.Debug().Msgf("Sending blockchain event: %d headers added, %d removed", len(event.Added), len(event.Removed))
+	return stream.Send(pbEvent)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"zero-provers/server/chain"
+	"zero-provers/server/dataset"
+)
+
+// URL paths of the control endpoints, used in manual mode.
+const (
+	controlAdvanceEndpoint = "/control/advance"
+	controlHeadEndpoint    = "/control/head/"
+)
+
+// Head of the chain moved by the control endpoints.
+var mockChain *chain.Chain
+
+// HeadResponse is the response of the control endpoints, describing the new head of the chain.
+type HeadResponse struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// Register the control endpoints, used to move the head of the chain in manual mode.
+func registerControlHandlers(c *chain.Chain) {
+	mockChain = c
+	http.HandleFunc(controlAdvanceEndpoint, advanceHandler)
+	http.HandleFunc(controlHeadEndpoint, headHandler)
+}
+
+// advanceHandler is the handler function for the `/control/advance?n={n}` endpoint.
+// It moves the head of the chain `n` blocks forward, one block by default.
+func advanceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Info().Msgf("Invalid request method on %s endpoint", controlAdvanceEndpoint)
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	n := 1
+	if param := r.URL.Query().Get("n"); param != "" {
+		var err error
+		n, err = strconv.Atoi(param)
+		if err != nil || n < 1 {
+			log.Error().Msgf("Invalid number of blocks: %s", param)
+			http.Error(w, "Invalid number of blocks, expected a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	log.Info().Msgf("POST request received on %s endpoint to advance %d blocks", controlAdvanceEndpoint, n)
+	writeHead(w, mockChain.Advance(n))
+}
+
+// headHandler is the handler function for the `/control/head/{number}` endpoint.
+// It moves the head of the chain to the given block number.
+func headHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Info().Msgf("Invalid request method on %s endpoint", controlHeadEndpoint)
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	param := strings.TrimPrefix(r.URL.Path, controlHeadEndpoint)
+	number, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		log.Error().Msgf("Invalid block number: %s", param)
+		http.Error(w, "Invalid block number", http.StatusBadRequest)
+		return
+	}
+
+	log.Info().Msgf("POST request received on %s endpoint to set the head to block #%d", controlHeadEndpoint, number)
+	head, err := mockChain.SetHead(number)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to set the head of the chain")
+		status := http.StatusNotFound
+		if errors.Is(err, chain.ErrTooFarAhead) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeHead(w, head)
+}
+
+// Write the head of the chain as a JSON response.
+func writeHead(w http.ResponseWriter, head *dataset.Entry) {
+	log.Info().Msgf("Chain head is now block #%d", head.Number)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(HeadResponse{
+		Number: head.Number,
+		Hash:   head.Block.Hash().String(),
+	}); err != nil {
+		log.Error().Err(err).Msg("Unable to encode the response")
+	}
+}
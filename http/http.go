@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"zero-provers/server/chain"
 	"zero-provers/server/logger"
+	"zero-provers/server/modes"
 
 	"github.com/rs/zerolog"
 )
@@ -30,11 +32,16 @@ type ServerConfig struct {
 	Port            int
 	SaveEndpoint    string
 	ProofsOutputDir string
+	Mode            modes.Mode
+	// Head of the chain moved by the control endpoints (used in manual mode).
+	Chain *chain.Chain
 }
 
 // StartHTTPServer starts an HTTP server on the specified port and sets up the necessary endpoints.
 // The server listens for incoming requests and handles them accordingly.
 // The `/save` endpoint allows clients to save data to a file in the specified output directory.
+// In manual mode, the `/control/advance` and `/control/head/{number}` endpoints allow clients to move
+// the head of the chain.
 func StartHTTPServer(config ServerConfig) error {
 	// Set up the logger.
 	lc := logger.LoggerConfig{
@@ -60,6 +67,9 @@ func StartHTTPServer(config ServerConfig) error {
 	// Start the HTTP server.
 	saveEndpoint = config.SaveEndpoint
 	http.HandleFunc(saveEndpoint, saveHandler)
+	if config.Mode == modes.ManualMode {
+		registerControlHandlers(config.Chain)
+	}
 	log.Debug().Msgf("HTTP server config: %+v", config)
 	log.Info().Msgf("HTTP server is listening on port %d", config.Port)
 	if err := http.ListenAndServe(fmt.Sprintf(":%d", config.Port), nil); err != nil {
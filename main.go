@@ -3,12 +3,20 @@ package main
 import (
 	"fmt"
 	"log"
-	"path/filepath"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+	"zero-provers/server/chain"
+	"zero-provers/server/dataset"
 	"zero-provers/server/grpc"
+	"zero-provers/server/grpc/edge"
 	"zero-provers/server/http"
 	"zero-provers/server/logger"
 	"zero-provers/server/modes"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 )
@@ -22,10 +30,19 @@ type Config struct {
 	// URL path of the HTTP server save endpoint.
 	HTTPServerSaveEndpoint string
 
+	//// Chain configuration.
+	// Chain ID reported by the gRPC server, and signing the random transactions.
+	ChainID int64
+	// Genesis hash reported by the gRPC server, the parent hash of the first block when empty.
+	GenesisHash string
+
 	// Mode of the mock server, either static or dynamic.
 	// - static: the server always return the same mock block data.
 	// - dynamic: the server returns new mock block data every x requests.
 	// - random: the server returns random block data every requests.
+	// - timed: the server returns new mock block data every block time.
+	// - manual: the server returns new mock block data when asked to, using the HTTP control endpoints.
+	// - replay: the server returns new mock block data following the timestamps of the blocks.
 	Mode string
 
 	//// Static mode configuration.
@@ -34,15 +51,49 @@ type Config struct {
 	MockTraceDir string
 
 	//// Dynamic mode configuration.
-	// Mock data directories (and underlying files) used in dynamic mode.
+	// Mock data directories (and underlying files) used in dynamic, timed, manual and replay modes.
 	MockBlockFile string
 	MockTraceFile string
+	// Dataset archive loaded in dynamic, timed, manual and replay modes, instead of the mock data directories.
+	DatasetArchive string
 	// Number of requests after which the server returns new data, block and trace (used in `dynamic` mode).
 	UpdateDataThreshold int
 
+	//// Timed mode configuration.
+	// Interval after which the server returns new data, block and trace (used in `timed` mode).
+	BlockTime time.Duration
+
+	//// Replay mode configuration.
+	// Factor by which the gaps between block timestamps are divided (used in `replay` mode).
+	ReplaySpeed float64
+
+	// Action taken once the end of the dataset is reached, either hold, loop or exit (used in every mode but random).
+	OnDatasetEnd string
+
+	// Watch the mock data files and directories, and reload them when they change (used in every mode but random).
+	WatchMockData bool
+
+	// Refuse to start when the mock block files fail to parse or their hashes don't match their content, or when traces don't
+	// match their blocks (used in every mode but random).
+	Strict bool
+
 	//// Random mode configuration.
 	// Number of requests after which the server increments the block number (used in `random` mode).
 	UpdateBlockNumberThreshold int
+	// Seed of the random data, picked at random when not set (used in `random` mode).
+	Seed int64
+	// Name of the profile shaping the random blocks and traces (used in `random` mode).
+	RandomProfile string
+	// Size of the random blocks and traces, overriding the profile when set (used in `random` mode).
+	TargetTxs              int
+	TargetGas              uint64
+	TargetAccountTrieNodes int
+	TargetStorageTrieNodes int
+	TargetTraceBytes       int
+	// Number of consecutive heights the target sizes are stepped through (used in `random` mode).
+	SweepSteps int
+	// Run the random transactions through the edge EVM, producing real witnesses and state roots (used in `random` mode).
+	RandomEVM bool
 
 	//// Other parameters.
 	// Directory in which proofs are stored.
@@ -65,29 +116,116 @@ func main() {
 			}
 			customLog := logger.NewLogger(lc)
 
-			// Check the mode.
-			switch modes.Mode(config.Mode) {
-			case modes.StaticMode, modes.RandomMode:
-				// Valid modes, no action needed.
-			case modes.DynamicMode:
-				// Check that the number of block and trace files are the same.
-				blockFiles, err := filepath.Glob(filepath.Join(config.MockBlockDir, "*.json"))
-				if err != nil {
-					return
-				}
-				traceFiles, err := filepath.Glob(filepath.Join(config.MockTraceDir, "*.json"))
-				if err != nil {
+			if modes.Mode(config.Mode) == modes.TimedMode && config.BlockTime <= 0 {
+				customLog.Fatal().Msgf("Block time must be positive, got %s", config.BlockTime)
+				return
+			}
+			if modes.Mode(config.Mode) == modes.ReplayMode && config.ReplaySpeed <= 0 {
+				customLog.Fatal().Msgf("Replay speed must be positive, got %v", config.ReplaySpeed)
+				return
+			}
+
+			// Pick a seed when none is given, it is logged so that the random data can be generated again.
+			if !cmd.Flags().Changed("seed") {
+				config.Seed = rand.Int63()
+			}
+
+			randomProfile, ok := edge.Profiles[config.RandomProfile]
+			if !ok {
+				customLog.Fatal().Msgf("Random profile '%s' is not supported... Please use one of %s.",
+					config.RandomProfile, strings.Join(edge.ProfileNames(), ", "))
+				return
+			}
+
+			for name, value := range map[string]int{
+				"target-txs":                config.TargetTxs,
+				"target-account-trie-nodes": config.TargetAccountTrieNodes,
+				"target-storage-trie-nodes": config.TargetStorageTrieNodes,
+				"target-trace-bytes":        config.TargetTraceBytes,
+				"sweep-steps":               config.SweepSteps,
+			} {
+				if value < 0 {
+					customLog.Fatal().Msgf("Flag --%s can't be negative, got %d", name, value)
 					return
 				}
-				if len(blockFiles) != len(traceFiles) {
-					customLog.Fatal().Msg("When running the mock server in dynamic mode, you need the same number of block and trace files")
+			}
+
+			var genesisHash *types.Hash
+			if config.GenesisHash != "" {
+				bytes, err := hex.DecodeHex(config.GenesisHash)
+				if err != nil || len(bytes) != types.HashLength {
+					customLog.Fatal().Msgf("Genesis hash '%s' is not a valid 32-byte hex string", config.GenesisHash)
 					return
 				}
+				hash := types.BytesToHash(bytes)
+				genesisHash = &hash
+			}
+
+			onDatasetEnd := chain.EndAction(config.OnDatasetEnd)
+			if onDatasetEnd != chain.HoldAction && onDatasetEnd != chain.LoopAction && onDatasetEnd != chain.ExitAction {
+				customLog.Fatal().Msgf("Action '%s' is not supported on dataset end... Please either use '%s', '%s' or '%s'.",
+					config.OnDatasetEnd, chain.HoldAction, chain.LoopAction, chain.ExitAction)
+				return
+			}
+
+			// Check the mode and load the mock data.
+			var store *dataset.Store
+			var err error
+			switch modes.Mode(config.Mode) {
+			case modes.StaticMode:
+				store, err = dataset.NewStore(dataset.Config{
+					LogLevel:  logLevel,
+					BlockFile: config.MockBlockFile,
+					TraceFile: config.MockTraceFile,
+					Watch:     config.WatchMockData,
+					Strict:    config.Strict,
+				})
+			case modes.DynamicMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode:
+				store, err = dataset.NewStore(dataset.Config{
+					LogLevel: logLevel,
+					BlockDir: config.MockBlockDir,
+					TraceDir: config.MockTraceDir,
+					Archive:  config.DatasetArchive,
+					Watch:    config.WatchMockData,
+					Strict:   config.Strict,
+				})
+			case modes.RandomMode:
+				// Valid mode, no mock data needed.
 			default:
-				customLog.Fatal().Msgf("Mode '%s' is not supported... Please either use '%s', '%s' or '%s'.",
-					config.Mode, modes.StaticMode, modes.DynamicMode, modes.RandomMode)
+				customLog.Fatal().Msgf("Mode '%s' is not supported... Please either use '%s', '%s', '%s', '%s', '%s' or '%s'.",
+					config.Mode, modes.StaticMode, modes.DynamicMode, modes.RandomMode, modes.TimedMode, modes.ManualMode, modes.ReplayMode)
 				return
 			}
+			if err != nil {
+				customLog.Fatal().Err(err).Msg("Unable to load the mock data")
+				return
+			}
+
+			// Set up the chain made of the mock data, and start producing blocks in timed and replay modes.
+			var mockChain *chain.Chain
+			if store != nil {
+				mockChain = chain.New(chain.Config{
+					LogLevel:     logLevel,
+					Dataset:      store,
+					OnDatasetEnd: onDatasetEnd,
+				})
+
+				// Shut the server down once the end of the dataset is reached in exit mode.
+				start := time.Now()
+				go func() {
+					<-mockChain.Done()
+					index := store.Index()
+					customLog.Info().Msgf("Shutting down after %s, end of the dataset of %d blocks from #%d to #%d reached",
+						time.Since(start).Round(time.Second), index.Len(), index.At(0).Number, index.At(index.Len()-1).Number)
+					os.Exit(0)
+				}()
+			}
+			switch modes.Mode(config.Mode) {
+			case modes.TimedMode:
+				go mockChain.Produce(config.BlockTime)
+			case modes.ReplayMode:
+				go mockChain.Replay(config.ReplaySpeed)
+			}
 
 			// Start the gRPC server.
 			go func() {
@@ -97,12 +235,20 @@ func main() {
 					Mode:                       modes.Mode(config.Mode),
 					UpdateDataThreshold:        config.UpdateDataThreshold,
 					UpdateBlockNumberThreshold: config.UpdateBlockNumberThreshold,
-					MockData: grpc.MockData{
-						BlockDir:  config.MockBlockDir,
-						TraceDir:  config.MockTraceDir,
-						BlockFile: config.MockBlockFile,
-						TraceFile: config.MockTraceFile,
+					Chain:                      mockChain,
+					ChainID:                    config.ChainID,
+					GenesisHash:                genesisHash,
+					Seed:                       config.Seed,
+					RandomProfile:              randomProfile,
+					RandomTarget: edge.Target{
+						Txs:              config.TargetTxs,
+						Gas:              config.TargetGas,
+						AccountTrieNodes: config.TargetAccountTrieNodes,
+						StorageTrieNodes: config.TargetStorageTrieNodes,
+						TraceBytes:       config.TargetTraceBytes,
 					},
+					SweepSteps:    config.SweepSteps,
+					ExecuteRandom: config.RandomEVM,
 				}))
 			}()
 
@@ -112,21 +258,86 @@ func main() {
 				Port:            config.HTTPServerPort,
 				SaveEndpoint:    config.HTTPServerSaveEndpoint,
 				ProofsOutputDir: config.ProofsOutputDir,
+				Mode:            modes.Mode(config.Mode),
+				Chain:           mockChain,
 			}))
 		},
 	}
 
+	var checkCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Check that the mock blocks match their content and the mock traces match their blocks",
+		Long: `Load the mock data like the server would, check the hashes and the transactions root of each block
+against its content, and check each trace against its block: the transactions, their hashes, the receipts and
+the gas used, and the parent state root. The witness of each trace is verified too, and every missing path of
+its tries is listed. A report is printed for every block, and the command fails if any block or trace is
+inconsistent.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Set up the logger.
+			logLevel := zerolog.Level(config.Verbosity)
+			lc := logger.LoggerConfig{
+				Level:       logLevel,
+				CallerField: "check",
+			}
+			customLog := logger.NewLogger(lc)
+
+			datasetConfig := dataset.Config{
+				LogLevel: logLevel,
+				BlockDir: config.MockBlockDir,
+				TraceDir: config.MockTraceDir,
+				Archive:  config.DatasetArchive,
+			}
+			switch modes.Mode(config.Mode) {
+			case modes.StaticMode:
+				datasetConfig = dataset.Config{
+					LogLevel:  logLevel,
+					BlockFile: config.MockBlockFile,
+					TraceFile: config.MockTraceFile,
+				}
+			case modes.RandomMode:
+				customLog.Fatal().Msg("There is no mock data to check in random mode")
+				return
+			}
+			datasetConfig.Strict = config.Strict
+
+			reports, err := dataset.Check(datasetConfig)
+			if err != nil {
+				customLog.Fatal().Err(err).Msg("Unable to load the mock data")
+				return
+			}
+			inconsistent := 0
+			for _, report := range reports {
+				fmt.Println(report)
+				if len(report.Errors) > 0 {
+					inconsistent++
+				}
+			}
+			fmt.Printf("%d blocks checked, %d inconsistent\n", len(reports), inconsistent)
+			if inconsistent > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+	rootCmd.AddCommand(checkCmd)
+
 	// Server configuration.
 	rootCmd.PersistentFlags().IntVarP(&config.GRPCServerPort, "grpc-port", "g", 8546, "gRPC server port")
 	rootCmd.PersistentFlags().IntVarP(&config.HTTPServerPort, "http-port", "p", 8080, "HTTP server port")
 	rootCmd.PersistentFlags().StringVarP(&config.HTTPServerSaveEndpoint, "http-save-endpoint", "e", "/save", "HTTP server save endpoint")
 
+	// Chain configuration.
+	rootCmd.PersistentFlags().Int64Var(&config.ChainID, "chain-id", 2001, "The chain ID reported by the gRPC server and signing the random transactions, the one of the provided datasets by default")
+	rootCmd.PersistentFlags().StringVar(&config.GenesisHash, "genesis-hash", "", "The genesis hash reported by the gRPC server, the parent hash of the first block by default")
+
 	// Server mode.
 	rootCmd.PersistentFlags().StringVarP(&config.Mode, "mode", "m", string(modes.StaticMode),
 		`Mode of the mock server.
 - static: the server always return the same mock block data.
 - dynamic: the server returns new mock block data every {n} requests.
 - random: the server returns random block data every requests.
+- timed: the server returns new mock block data every {block-time}.
+- manual: the server returns new mock block data when asked to, using the HTTP control endpoints.
+- replay: the server returns new mock block data following the timestamps of the blocks.
 `)
 
 	// Static mode configuration.
@@ -134,12 +345,38 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&config.MockTraceFile, "mock-data-trace-file", "data/traces/trace_121.json", "The mock data trace file path (used in static mode)")
 
 	// Dynamic mode configuration.
-	rootCmd.PersistentFlags().StringVar(&config.MockBlockDir, "mock-data-block-dir", "data/blocks", "The mock data block directory (used in dynamic mode)")
-	rootCmd.PersistentFlags().StringVar(&config.MockTraceDir, "mock-data-trace-dir", "data/traces", "The mock data trace directory (used in dynamic mode)")
+	rootCmd.PersistentFlags().StringVar(&config.MockBlockDir, "mock-data-block-dir", "data/blocks", "The mock data block directory (used in dynamic, timed, manual and replay modes)")
+	rootCmd.PersistentFlags().StringVar(&config.MockTraceDir, "mock-data-trace-dir", "data/traces", "The mock data trace directory (used in dynamic, timed, manual and replay modes)")
+	rootCmd.PersistentFlags().StringVar(&config.DatasetArchive, "dataset-archive", "", "The dataset archive (.tar.bz2, .tar.gz or .zip) to load instead of the mock data directories (used in dynamic, timed, manual and replay modes)")
 	rootCmd.PersistentFlags().IntVar(&config.UpdateDataThreshold, "update-data-threshold", 30, "The number of requests after which the server returns new data, block and trace (used in dynamic mode).")
 
+	// Timed mode configuration.
+	rootCmd.PersistentFlags().DurationVar(&config.BlockTime, "block-time", 2*time.Second, "The interval after which the server returns new data, block and trace (used in timed mode)")
+
+	// Replay mode configuration.
+	rootCmd.PersistentFlags().Float64Var(&config.ReplaySpeed, "replay-speed", 1, "The factor by which the gaps between block timestamps are divided, e.g. 2 replays the blocks twice as fast (used in replay mode)")
+
+	rootCmd.PersistentFlags().StringVar(&config.OnDatasetEnd, "on-dataset-end", string(chain.HoldAction),
+		`The action taken once the end of the dataset is reached (used in every mode but random).
+- hold: the server keeps returning the last block.
+- loop: the server restarts from the first block, renumbered so that heights keep increasing.
+- exit: the server shuts down.
+`)
+	rootCmd.PersistentFlags().BoolVar(&config.WatchMockData, "watch-mock-data", true, "Reload the mock data when the files change (used in every mode but random)")
+	rootCmd.PersistentFlags().BoolVar(&config.Strict, "strict", false, "Refuse to start when mock block files fail to parse or their hashes don't match their content, or when traces don't match their blocks, instead of skipping them or logging the mismatches (used in every mode but random)")
+
 	// Random mode configuration.
 	rootCmd.PersistentFlags().IntVar(&config.UpdateBlockNumberThreshold, "update-block-number-threshold", 30, "The number of requests after which the server increments the block number (used in random mode)")
+	rootCmd.PersistentFlags().StringVar(&config.RandomProfile, "random-profile", edge.DefaultProfile,
+		fmt.Sprintf("The profile shaping the random blocks and traces, one of %s (used in random mode)", strings.Join(edge.ProfileNames(), ", ")))
+	rootCmd.PersistentFlags().IntVar(&config.TargetTxs, "target-txs", 0, "The number of transactions of the random blocks, set by the profile when zero (used in random mode)")
+	rootCmd.PersistentFlags().Uint64Var(&config.TargetGas, "target-gas", 0, "The gas used by the random blocks, set by the profile when zero (used in random mode)")
+	rootCmd.PersistentFlags().IntVar(&config.TargetAccountTrieNodes, "target-account-trie-nodes", 0, "The number of account trie nodes of the random traces, set by the profile when zero (used in random mode)")
+	rootCmd.PersistentFlags().IntVar(&config.TargetStorageTrieNodes, "target-storage-trie-nodes", 0, "The number of storage trie nodes of the random traces, set by the profile when zero (used in random mode)")
+	rootCmd.PersistentFlags().IntVar(&config.TargetTraceBytes, "target-trace-bytes", 0, "The approximate size of the JSON-encoded random traces, set by the profile when zero (used in random mode)")
+	rootCmd.PersistentFlags().IntVar(&config.SweepSteps, "sweep-steps", 0, "The number of consecutive heights stepping the targets from 1 to {n} times their value, before starting over (used in random mode)")
+	rootCmd.PersistentFlags().BoolVar(&config.RandomEVM, "random-evm", false, "Run the random transactions through the edge EVM, so that traces hold real witnesses and blocks real state roots (used in random mode)")
+	rootCmd.PersistentFlags().Int64Var(&config.Seed, "seed", 0, "The seed of the random data, the same seed always yields the same blocks and traces, picked at random by default (used in random mode)")
 
 	// Other parameters.
 	rootCmd.PersistentFlags().StringVarP(&config.ProofsOutputDir, "output-dir", "o", "out", "The proofs output directory")
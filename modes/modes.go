@@ -6,4 +6,7 @@ var (
 	StaticMode  Mode = "static"
 	DynamicMode Mode = "dynamic"
 	RandomMode  Mode = "random"
+	TimedMode   Mode = "timed"
+	ManualMode  Mode = "manual"
+	ReplayMode  Mode = "replay"
 )